@@ -6,7 +6,10 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"os/signal"
 	"runtime"
+	"strconv"
+	"syscall"
 
 	"github.com/bryant-rh/cloud_dns_exporter/pkg/export"
 	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
@@ -32,6 +35,13 @@ func init() {
   OS/Arch:    %s/%s
   Build Time: %s`, GitCommit, runtime.Version(), runtime.GOOS, runtime.GOARCH, BuildTime))
 	rootCmd.Flags().BoolP("version", "v", false, "Show version information")
+	rootCmd.Flags().StringP("config", "c", "config.yaml", "Path to the config file (env: CONFIG_FILE)")
+	rootCmd.Flags().String("listen-address", ":21798", "Address to listen on for HTTP requests, e.g. 0.0.0.0:21798 (env: LISTEN_ADDRESS)")
+	rootCmd.Flags().String("log-level", "debug", "Log level, one of debug, info, warn, error (env: LOG_LEVEL)")
+	rootCmd.Flags().String("log-format", "text", "Log format, one of text, json, logfmt (env: LOG_FORMAT)")
+	rootCmd.Flags().String("namespace", "", "Namespace prefix of the exported metrics (env: METRICS_NAMESPACE)")
+	rootCmd.Flags().String("snapshot-file", "", "Path to the snapshot file used to persist collected data across restarts, disabled if empty (env: SNAPSHOT_FILE)")
+	rootCmd.Flags().Bool("web.enable-reload", false, "Enable the /-/reload endpoint to reload the config via POST requests (env: WEB_ENABLE_RELOAD)")
 }
 
 func Execute() {
@@ -46,16 +56,69 @@ var rootCmd = &cobra.Command{
 			fmt.Println(cmd.VersionTemplate())
 			return
 		}
-		logger.InitLogger("debug")
-		public.InitSvc()
+		logger.InitLogger(flagOrEnv(cmd, "log-level", "LOG_LEVEL"), flagOrEnv(cmd, "log-format", "LOG_FORMAT"))
+		public.InitSvc(flagOrEnv(cmd, "config", "CONFIG_FILE"))
+		public.InitSnapshot(flagOrEnv(cmd, "snapshot-file", "SNAPSHOT_FILE"))
 		logger.Info("🚀 Start Cloud DNS Exporter, The Metrics Data Is Loading...")
 		export.InitCron()
-		RunServer()
+		watchReload()
+		RunServer(listenAddress(cmd), flagOrEnv(cmd, "namespace", "METRICS_NAMESPACE"), boolFlagOrEnv(cmd, "web.enable-reload", "WEB_ENABLE_RELOAD"))
 	},
 }
 
-func RunServer() {
-	metrics := export.NewMetrics("")
+// flagOrEnv 获取参数值，优先级：命令行参数 > 环境变量 > 参数默认值
+func flagOrEnv(cmd *cobra.Command, name, env string) string {
+	value, _ := cmd.Flags().GetString(name)
+	if cmd.Flags().Changed(name) {
+		return value
+	}
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	return value
+}
+
+// boolFlagOrEnv 获取布尔参数值，优先级与 flagOrEnv 一致，环境变量无法解析时使用参数默认值
+func boolFlagOrEnv(cmd *cobra.Command, name, env string) bool {
+	value, _ := cmd.Flags().GetBool(name)
+	if cmd.Flags().Changed(name) {
+		return value
+	}
+	if v, err := strconv.ParseBool(os.Getenv(env)); err == nil {
+		return v
+	}
+	return value
+}
+
+// listenAddress 获取监听地址，兼容旧的 PORT 环境变量
+func listenAddress(cmd *cobra.Command) string {
+	if !cmd.Flags().Changed("listen-address") && os.Getenv("LISTEN_ADDRESS") == "" {
+		if port := os.Getenv("PORT"); port != "" {
+			return ":" + port
+		}
+	}
+	return flagOrEnv(cmd, "listen-address", "LISTEN_ADDRESS")
+}
+
+// watchReload 监听 SIGHUP 信号与配置文件变化，触发配置热加载
+func watchReload() {
+	if err := export.WatchConfig(public.ConfigFile); err != nil {
+		logger.Error("Watch Config File Error: ", err)
+	}
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	go func() {
+		for range sighup {
+			logger.Info("收到 SIGHUP 信号，重新加载配置...")
+			if err := export.Reload(); err != nil {
+				logger.Error("Reload Config Error: ", err)
+			}
+		}
+	}()
+}
+
+func RunServer(listenAddress, namespace string, enableReload bool) {
+	metrics := export.NewMetrics(namespace)
 	registory := prometheus.NewRegistry()
 	registory.MustRegister(metrics)
 
@@ -74,12 +137,34 @@ func RunServer() {
 		}
 	})
 	http.Handle("/metrics", promhttp.HandlerFor(registory, promhttp.HandlerOpts{Registry: registory}))
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "21798"
+	http.HandleFunc("/-/healthy", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	})
+	http.HandleFunc("/-/ready", func(w http.ResponseWriter, r *http.Request) {
+		if !export.Ready() {
+			http.Error(w, "Initial collection in progress", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("OK"))
+	})
+	// 重新加载接口无鉴权，仅在显式开启时注册
+	if enableReload {
+		http.HandleFunc("/-/reload", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				w.Header().Set("Allow", http.MethodPost)
+				http.Error(w, "Only POST requests allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			if err := export.Reload(); err != nil {
+				logger.Error("Reload Config Error: ", err)
+				http.Error(w, fmt.Sprintf("failed to reload config: %v", err), http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte("OK"))
+		})
 	}
-	logger.Info("🚀 The Server Listen On Port " + port + ", Enjoy it 🎉")
-	if err := http.ListenAndServe(":"+port, nil); err != nil {
+	logger.Info("🚀 The Server Listen On " + listenAddress + ", Enjoy it 🎉")
+	if err := http.ListenAndServe(listenAddress, nil); err != nil {
 		log.Fatalf("ListenAndServe: %v", err)
 	}
 }
@@ -0,0 +1,34 @@
+package digitalocean
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Client DigitalOcean Domains 客户端
+type Client struct {
+	client *resty.Client
+
+	// Services
+	Domains *DomainService
+	Records *RecordService
+}
+
+var baseUrl = "https://api.digitalocean.com/v2"
+
+// NewClient 使用个人访问令牌初始化客户端
+func NewClient(token string) (*Client, error) {
+	c := new(Client)
+	if token == "" {
+		return c, errors.New("missing digitalocean API token")
+	}
+	c.client = resty.New().SetBaseURL(baseUrl).SetAuthToken(token).
+		SetTimeout(10 * time.Second).SetRetryCount(3).SetRetryWaitTime(2 * time.Second)
+	// Initialize services
+	c.Domains = &DomainService{c}
+	c.Records = &RecordService{c}
+
+	return c, nil
+}
@@ -0,0 +1,34 @@
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// DomainService 域名服务
+type DomainService struct{ *Client }
+
+// List 获取域名列表
+// https://docs.digitalocean.com/reference/api/digitalocean/#tag/Domains/operation/domains_list
+func (d *DomainService) List(ctx context.Context, page PageOption) (*DomainListResponse, error) {
+	resp, err := d.client.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"page":     strconv.Itoa(page.Page),
+			"per_page": strconv.Itoa(page.PerPage),
+		}).
+		SetResult(&DomainListResponse{}).
+		Get("/domains")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*DomainListResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *DomainListResponse")
+	}
+	return result, nil
+}
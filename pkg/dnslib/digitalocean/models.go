@@ -0,0 +1,50 @@
+package digitalocean
+
+// DomainListResponse 域名列表响应
+type DomainListResponse struct {
+	Domains []Domain `json:"domains"`
+	Links   Links    `json:"links"`
+	Meta    Meta     `json:"meta"`
+}
+
+// Domain 域名
+type Domain struct {
+	Name string `json:"name"` // 域名
+	TTL  int    `json:"ttl"`  // 默认 TTL
+}
+
+// RecordListResponse 解析记录列表响应
+type RecordListResponse struct {
+	DomainRecords []Record `json:"domain_records"`
+	Links         Links    `json:"links"`
+	Meta          Meta     `json:"meta"`
+}
+
+// Record 解析记录
+type Record struct {
+	ID       int     `json:"id"`       // 记录ID
+	Type     string  `json:"type"`     // 记录类型
+	Name     string  `json:"name"`     // 主机记录，@ 表示根域名
+	Data     string  `json:"data"`     // 记录值
+	Priority *int    `json:"priority"` // MX/SRV 优先级
+	Port     *int    `json:"port"`     // SRV 端口
+	TTL      int     `json:"ttl"`      // TTL
+	Weight   *int    `json:"weight"`   // SRV 权重
+	Flags    *int    `json:"flags"`    // CAA 标志
+	Tag      *string `json:"tag"`      // CAA 标签
+}
+
+// Links 分页链接，没有下一页时 Next 为空
+type Links struct {
+	Pages struct {
+		First string `json:"first"`
+		Prev  string `json:"prev"`
+		Next  string `json:"next"`
+		Last  string `json:"last"`
+	} `json:"pages"`
+}
+
+// Meta 列表元数据
+type Meta struct {
+	Total int `json:"total"` // 总数
+}
@@ -0,0 +1,18 @@
+package digitalocean
+
+// PageOption 分页参数
+type PageOption struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+// NewPageOption 创建一个分页参数，page 从 1 开始，perPage 取值范围 1~200
+func NewPageOption(page, perPage int) PageOption {
+	if perPage <= 0 || perPage > 200 {
+		perPage = 200
+	}
+	if page < 1 {
+		page = 1
+	}
+	return PageOption{Page: page, PerPage: perPage}
+}
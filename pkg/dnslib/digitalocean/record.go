@@ -0,0 +1,35 @@
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// RecordService 解析记录服务
+type RecordService struct{ *Client }
+
+// List 获取域名解析记录列表
+// https://docs.digitalocean.com/reference/api/digitalocean/#tag/Domain-Records/operation/domains_list_records
+func (r *RecordService) List(ctx context.Context, page PageOption, domain string) (*RecordListResponse, error) {
+	resp, err := r.client.R().
+		SetContext(ctx).
+		SetPathParam("domain", domain).
+		SetQueryParams(map[string]string{
+			"page":     strconv.Itoa(page.Page),
+			"per_page": strconv.Itoa(page.PerPage),
+		}).
+		SetResult(&RecordListResponse{}).
+		Get("/domains/{domain}/records")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*RecordListResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *RecordListResponse")
+	}
+	return result, nil
+}
@@ -1,6 +1,7 @@
 package dnsla
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -10,7 +11,7 @@ import (
 type DomainService struct{ *Client }
 
 // List 获取域名列表
-func (d *DomainService) List(page PageOption, options ...DomainListOption) (*DomainListResponse, error) {
+func (d *DomainService) List(ctx context.Context, page PageOption, options ...DomainListOption) (*DomainListResponse, error) {
 	params := url.Values{}
 	params.Set("pageIndex", strconv.Itoa(page.PageIndex))
 	params.Set("pageSize", strconv.Itoa(page.PageSize))
@@ -18,6 +19,7 @@ func (d *DomainService) List(page PageOption, options ...DomainListOption) (*Dom
 		option(params)
 	}
 	resp, err := d.client.R().
+		SetContext(ctx).
 		SetQueryParamsFromValues(params).
 		SetResult(&DomainListResponse{}).
 		Get("/api/domainList")
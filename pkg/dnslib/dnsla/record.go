@@ -1,6 +1,7 @@
 package dnsla
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -10,7 +11,7 @@ import (
 type RecordService struct{ *Client }
 
 // ListRecords 获取域名解析记录列表
-func (r *RecordService) List(page PageOption, domainID string, options ...RecordListOption) (*RecordListResponse, error) {
+func (r *RecordService) List(ctx context.Context, page PageOption, domainID string, options ...RecordListOption) (*RecordListResponse, error) {
 	params := url.Values{}
 	params.Set("pageIndex", strconv.Itoa(page.PageIndex))
 	params.Set("pageSize", strconv.Itoa(page.PageSize))
@@ -19,6 +20,7 @@ func (r *RecordService) List(page PageOption, domainID string, options ...Record
 		option(params)
 	}
 	resp, err := r.client.R().
+		SetContext(ctx).
 		SetQueryParamsFromValues(params).
 		SetResult(&RecordListResponse{}).
 		Get("/api/recordList")
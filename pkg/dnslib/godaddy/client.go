@@ -0,0 +1,40 @@
+package godaddy
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Client GoDaddy 客户端
+type Client struct {
+	client *resty.Client
+
+	// Services
+	Domains *DomainService
+	Records *RecordService
+}
+
+var baseUrl = "https://api.godaddy.com"
+
+// NewClient 初始化客户端，请求均携带 context 并设置超时，避免请求挂起时 goroutine 与连接堆积
+func NewClient(key, secret string) (*Client, error) {
+	c := new(Client)
+	if key == "" {
+		return c, errors.New("missing GoDaddy API key")
+	}
+	if secret == "" {
+		return c, errors.New("missing GoDaddy API secret")
+	}
+	c.client = resty.New().SetBaseURL(baseUrl).
+		SetHeader("Authorization", fmt.Sprintf("sso-key %s:%s", key, secret)).
+		SetHeader("Accept", "application/json").
+		SetTimeout(10 * time.Second).SetRetryCount(3).SetRetryWaitTime(2 * time.Second)
+	// Initialize services
+	c.Domains = &DomainService{c}
+	c.Records = &RecordService{c}
+
+	return c, nil
+}
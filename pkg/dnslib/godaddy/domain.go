@@ -0,0 +1,26 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+)
+
+// DomainService 域名服务
+type DomainService struct{ *Client }
+
+// List 获取域名列表
+// https://developer.godaddy.com/doc/endpoint/domains#/v1/list
+func (d *DomainService) List(ctx context.Context) ([]Domain, error) {
+	var result []Domain
+	resp, err := d.client.R().
+		SetContext(ctx).
+		SetResult(&result).
+		Get("/v1/domains")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	return result, nil
+}
@@ -0,0 +1,21 @@
+package godaddy
+
+// Domain 域名
+type Domain struct {
+	DomainID  int    `json:"domainId"`  // 域名ID
+	Domain    string `json:"domain"`    // 域名
+	Status    string `json:"status"`    // 状态
+	CreatedAt string `json:"createdAt"` // 创建时间
+	Expires   string `json:"expires"`   // 到期时间
+}
+
+// Record 解析记录
+type Record struct {
+	Type     string `json:"type"`     // 记录类型
+	Name     string `json:"name"`     // 主机记录
+	Data     string `json:"data"`     // 记录值
+	TTL      int    `json:"ttl"`      // TTL
+	Priority int    `json:"priority"` // MX/SRV 优先级
+	Weight   int    `json:"weight"`   // SRV 权重
+	Port     int    `json:"port"`     // SRV 端口
+}
@@ -0,0 +1,32 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// RecordService 解析记录服务
+type RecordService struct{ *Client }
+
+// List 获取域名解析记录列表
+// https://developer.godaddy.com/doc/endpoint/domains#/v1/recordGet
+func (r *RecordService) List(ctx context.Context, domain string, offset, limit int) ([]Record, error) {
+	var result []Record
+	resp, err := r.client.R().
+		SetContext(ctx).
+		SetPathParam("domain", domain).
+		SetQueryParams(map[string]string{
+			"offset": strconv.Itoa(offset),
+			"limit":  strconv.Itoa(limit),
+		}).
+		SetResult(&result).
+		Get("/v1/domains/{domain}/records")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package google
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// Client Google Cloud DNS 客户端
+type Client struct {
+	client *resty.Client
+
+	// ProjectID 服务账号密钥所属的项目
+	ProjectID string
+
+	// Services
+	ManagedZones       *ManagedZoneService
+	ResourceRecordSets *ResourceRecordSetService
+}
+
+var baseUrl = "https://dns.googleapis.com/dns/v1"
+
+// scope 只读权限
+const scope = "https://www.googleapis.com/auth/ndev.clouddns.readonly"
+
+// NewClient 使用服务账号 JSON 密钥初始化客户端
+func NewClient(ctx context.Context, credentialsJSON []byte) (*Client, error) {
+	c := new(Client)
+	if len(credentialsJSON) == 0 {
+		return c, errors.New("missing google cloud service account key")
+	}
+	// 仅接受服务账号类型的密钥，避免加载来源不可信的外部账号等其他类型凭证
+	creds, err := google.CredentialsFromJSONWithType(ctx, credentialsJSON, google.ServiceAccount, scope)
+	if err != nil {
+		return c, err
+	}
+	c.ProjectID = creds.ProjectID
+	c.client = resty.NewWithClient(oauth2.NewClient(ctx, creds.TokenSource)).SetBaseURL(baseUrl).
+		SetTimeout(10 * time.Second).SetRetryCount(3).SetRetryWaitTime(2 * time.Second)
+	// Initialize services
+	c.ManagedZones = &ManagedZoneService{c}
+	c.ResourceRecordSets = &ResourceRecordSetService{c}
+
+	return c, nil
+}
@@ -0,0 +1,33 @@
+package google
+
+import (
+	"context"
+	"fmt"
+)
+
+// ManagedZoneService 托管区域服务
+type ManagedZoneService struct{ *Client }
+
+// List 获取项目下的托管区域列表，pageToken 为空时获取第一页
+// https://cloud.google.com/dns/docs/reference/rest/v1/managedZones/list
+func (m *ManagedZoneService) List(ctx context.Context, project, pageToken string) (*ManagedZoneListResponse, error) {
+	req := m.client.R().
+		SetContext(ctx).
+		SetPathParam("project", project).
+		SetResult(&ManagedZoneListResponse{})
+	if pageToken != "" {
+		req.SetQueryParam("pageToken", pageToken)
+	}
+	resp, err := req.Get("/projects/{project}/managedZones")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*ManagedZoneListResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *ManagedZoneListResponse")
+	}
+	return result, nil
+}
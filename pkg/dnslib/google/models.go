@@ -0,0 +1,45 @@
+package google
+
+// 域名可见性
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
+// ManagedZoneListResponse 托管区域列表响应
+type ManagedZoneListResponse struct {
+	ManagedZones  []ManagedZone `json:"managedZones"`
+	NextPageToken string        `json:"nextPageToken"`
+}
+
+// ManagedZone 托管区域
+type ManagedZone struct {
+	ID                      string                   `json:"id"`           // 托管区域ID
+	Name                    string                   `json:"name"`         // 托管区域名称，项目内唯一
+	DNSName                 string                   `json:"dnsName"`      // 域名，以 . 结尾
+	Description             string                   `json:"description"`  // 描述
+	Visibility              string                   `json:"visibility"`   // 可见性 public/private
+	CreationTime            string                   `json:"creationTime"` // 创建时间 RFC3339
+	PrivateVisibilityConfig *PrivateVisibilityConfig `json:"privateVisibilityConfig"`
+}
+
+// PrivateVisibilityConfig 内网域名关联的 VPC 网络
+type PrivateVisibilityConfig struct {
+	Networks []struct {
+		NetworkURL string `json:"networkUrl"`
+	} `json:"networks"`
+}
+
+// ResourceRecordSetListResponse 记录集列表响应
+type ResourceRecordSetListResponse struct {
+	RRSets        []ResourceRecordSet `json:"rrsets"`
+	NextPageToken string              `json:"nextPageToken"`
+}
+
+// ResourceRecordSet 记录集，一个记录集可以包含多个记录值
+type ResourceRecordSet struct {
+	Name    string   `json:"name"`    // 记录名，以 . 结尾
+	Type    string   `json:"type"`    // 记录类型
+	TTL     int      `json:"ttl"`     // TTL
+	RRDatas []string `json:"rrdatas"` // 记录值
+}
@@ -0,0 +1,36 @@
+package google
+
+import (
+	"context"
+	"fmt"
+)
+
+// ResourceRecordSetService 记录集服务
+type ResourceRecordSetService struct{ *Client }
+
+// List 获取托管区域下的记录集列表，pageToken 为空时获取第一页
+// https://cloud.google.com/dns/docs/reference/rest/v1/resourceRecordSets/list
+func (r *ResourceRecordSetService) List(ctx context.Context, project, managedZone, pageToken string) (*ResourceRecordSetListResponse, error) {
+	req := r.client.R().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			"project":     project,
+			"managedZone": managedZone,
+		}).
+		SetResult(&ResourceRecordSetListResponse{})
+	if pageToken != "" {
+		req.SetQueryParam("pageToken", pageToken)
+	}
+	resp, err := req.Get("/projects/{project}/managedZones/{managedZone}/rrsets")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*ResourceRecordSetListResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *ResourceRecordSetListResponse")
+	}
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package huawei
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Client 华为云 DNS 客户端
+type Client struct {
+	client *resty.Client
+
+	// Services
+	Zones      *ZoneService
+	RecordSets *RecordSetService
+}
+
+// DefaultRegion 默认地域
+const DefaultRegion = "cn-north-4"
+
+// Endpoint 获取地域对应的 DNS 接口地址
+func Endpoint(region string) string {
+	if region == "" {
+		region = DefaultRegion
+	}
+	return "https://dns." + region + ".myhuaweicloud.com"
+}
+
+// NewClient 初始化客户端，projectID 为空时使用 AK 所属账号的默认项目
+func NewClient(accessKey, secretKey, endpoint, projectID string) (*Client, error) {
+	c := new(Client)
+	if accessKey == "" {
+		return c, errors.New("missing huawei cloud access key")
+	}
+	if secretKey == "" {
+		return c, errors.New("missing huawei cloud secret key")
+	}
+	signer := &Signer{AccessKey: accessKey, SecretKey: secretKey}
+	c.client = resty.New().SetBaseURL(endpoint).
+		SetTimeout(10 * time.Second).SetRetryCount(3).SetRetryWaitTime(2 * time.Second).
+		SetPreRequestHook(func(_ *resty.Client, r *http.Request) error {
+			if projectID != "" {
+				r.Header.Set("X-Project-Id", projectID)
+			}
+			return signer.Sign(r)
+		})
+	// Initialize services
+	c.Zones = &ZoneService{c}
+	c.RecordSets = &RecordSetService{c}
+
+	return c, nil
+}
@@ -0,0 +1,82 @@
+package huawei
+
+// 域名类型
+const (
+	ZoneTypePublic  = "public"
+	ZoneTypePrivate = "private"
+)
+
+// Metadata 分页信息
+type Metadata struct {
+	TotalCount int `json:"total_count"` // 资源总数
+}
+
+// ZoneListResponse 域名列表响应
+type ZoneListResponse struct {
+	Zones    []Zone   `json:"zones"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// Zone 域名
+type Zone struct {
+	ID          string   `json:"id"`          // 域名ID
+	Name        string   `json:"name"`        // 域名，以 . 结尾
+	Description string   `json:"description"` // 域名描述
+	Email       string   `json:"email"`       // 管理员邮箱
+	TTL         int      `json:"ttl"`         // 默认 TTL
+	Status      string   `json:"status"`      // 资源状态，如 ACTIVE、DISABLE、PENDING_CREATE
+	ZoneType    string   `json:"zone_type"`   // 域名类型 public/private
+	RecordNum   int      `json:"record_num"`  // 记录集数量
+	ProjectID   string   `json:"project_id"`  // 项目ID
+	CreatedAt   string   `json:"created_at"`  // 创建时间，如 2017-11-10T03:45:56.093
+	UpdatedAt   string   `json:"updated_at"`  // 更新时间
+	Routers     []Router `json:"routers"`     // 内网域名关联的 VPC
+}
+
+// Router 内网域名关联的 VPC
+type Router struct {
+	RouterID     string `json:"router_id"`     // VPC ID
+	RouterRegion string `json:"router_region"` // VPC 所在地域
+	Status       string `json:"status"`        // 关联状态
+}
+
+// RecordSetListResponse 记录集列表响应
+type RecordSetListResponse struct {
+	RecordSets []RecordSet `json:"recordsets"`
+	Metadata   Metadata    `json:"metadata"`
+}
+
+// RecordSet 记录集，一个记录集可以包含多个记录值
+type RecordSet struct {
+	ID          string   `json:"id"`          // 记录集ID
+	Name        string   `json:"name"`        // 记录集名称，以 . 结尾
+	Description string   `json:"description"` // 记录集描述
+	ZoneID      string   `json:"zone_id"`     // 域名ID
+	ZoneName    string   `json:"zone_name"`   // 域名
+	Type        string   `json:"type"`        // 记录类型
+	TTL         int      `json:"ttl"`         // TTL
+	Records     []string `json:"records"`     // 记录值
+	Status      string   `json:"status"`      // 资源状态
+	Default     bool     `json:"default"`     // 是否系统默认记录集
+	Line        string   `json:"line"`        // 解析线路，仅公网域名
+	Weight      *int     `json:"weight"`      // 权重，仅公网域名
+	CreatedAt   string   `json:"created_at"`  // 创建时间
+	UpdatedAt   string   `json:"updated_at"`  // 更新时间
+}
+
+// PageOption 分页参数
+type PageOption struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+// NewPageOption 创建一个分页参数，limit 取值范围 1~500
+func NewPageOption(offset, limit int) PageOption {
+	if limit <= 0 || limit > 500 {
+		limit = 500
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return PageOption{Offset: offset, Limit: limit}
+}
@@ -0,0 +1,41 @@
+package huawei
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// RecordSetService 记录集服务
+type RecordSetService struct{ *Client }
+
+// List 获取域名下的记录集列表
+// 公网域名使用支持线路与权重的 v2.1 接口，内网域名使用 v2 接口
+// https://support.huaweicloud.com/api-dns/ShowRecordSetByZone.html
+// https://support.huaweicloud.com/api-dns/ListRecordSetsByZone.html
+func (r *RecordSetService) List(ctx context.Context, page PageOption, zoneType, zoneID string) (*RecordSetListResponse, error) {
+	path := "/v2.1/zones/{zone_id}/recordsets"
+	if zoneType == ZoneTypePrivate {
+		path = "/v2/zones/{zone_id}/recordsets"
+	}
+	resp, err := r.client.R().
+		SetContext(ctx).
+		SetPathParam("zone_id", zoneID).
+		SetQueryParams(map[string]string{
+			"offset": strconv.Itoa(page.Offset),
+			"limit":  strconv.Itoa(page.Limit),
+		}).
+		SetResult(&RecordSetListResponse{}).
+		Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*RecordSetListResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *RecordSetListResponse")
+	}
+	return result, nil
+}
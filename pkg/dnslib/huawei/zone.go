@@ -0,0 +1,36 @@
+package huawei
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ZoneService 域名服务
+type ZoneService struct{ *Client }
+
+// List 获取域名列表，zoneType 为 public 或 private
+// https://support.huaweicloud.com/api-dns/ListPublicZones.html
+// https://support.huaweicloud.com/api-dns/ListPrivateZones.html
+func (z *ZoneService) List(ctx context.Context, page PageOption, zoneType string) (*ZoneListResponse, error) {
+	resp, err := z.client.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"type":   zoneType,
+			"offset": strconv.Itoa(page.Offset),
+			"limit":  strconv.Itoa(page.Limit),
+		}).
+		SetResult(&ZoneListResponse{}).
+		Get("/v2/zones")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*ZoneListResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *ZoneListResponse")
+	}
+	return result, nil
+}
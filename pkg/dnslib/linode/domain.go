@@ -0,0 +1,34 @@
+package linode
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// DomainService 域名服务
+type DomainService struct{ *Client }
+
+// List 获取域名列表
+// https://techdocs.akamai.com/linode-api/reference/get-domains
+func (d *DomainService) List(ctx context.Context, page PageOption) (*DomainListResponse, error) {
+	resp, err := d.client.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"page":      strconv.Itoa(page.Page),
+			"page_size": strconv.Itoa(page.PageSize),
+		}).
+		SetResult(&DomainListResponse{}).
+		Get("/domains")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*DomainListResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *DomainListResponse")
+	}
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package linode
+
+// DomainListResponse 域名列表响应
+type DomainListResponse struct {
+	Data    []Domain `json:"data"`
+	Page    int      `json:"page"`    // 当前页，从 1 开始
+	Pages   int      `json:"pages"`   // 总页数
+	Results int      `json:"results"` // 总数
+}
+
+// Domain 域名
+type Domain struct {
+	ID          int      `json:"id"`          // 域名ID
+	Domain      string   `json:"domain"`      // 域名
+	Type        string   `json:"type"`        // 类型 master/slave
+	Status      string   `json:"status"`      // 状态 active/disabled/edit_mode/has_errors
+	Description string   `json:"description"` // 描述
+	SOAEmail    string   `json:"soa_email"`   // SOA 邮箱
+	Tags        []string `json:"tags"`        // 标签
+	TTLSec      int      `json:"ttl_sec"`     // 默认 TTL
+}
+
+// RecordListResponse 解析记录列表响应
+type RecordListResponse struct {
+	Data    []Record `json:"data"`
+	Page    int      `json:"page"`    // 当前页，从 1 开始
+	Pages   int      `json:"pages"`   // 总页数
+	Results int      `json:"results"` // 总数
+}
+
+// Record 解析记录
+type Record struct {
+	ID       int     `json:"id"`       // 记录ID
+	Type     string  `json:"type"`     // 记录类型
+	Name     string  `json:"name"`     // 主机记录，空表示根域名
+	Target   string  `json:"target"`   // 记录值
+	Priority int     `json:"priority"` // MX/SRV 优先级
+	Weight   int     `json:"weight"`   // SRV 权重
+	Port     int     `json:"port"`     // SRV 端口
+	Service  *string `json:"service"`  // SRV 服务名
+	Protocol *string `json:"protocol"` // SRV 协议
+	Tag      *string `json:"tag"`      // CAA 标签
+	TTLSec   int     `json:"ttl_sec"`  // TTL，0 表示使用域名的默认 TTL
+	Created  string  `json:"created"`  // 创建时间
+	Updated  string  `json:"updated"`  // 最后修改时间
+}
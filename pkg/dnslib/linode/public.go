@@ -0,0 +1,18 @@
+package linode
+
+// PageOption 分页参数
+type PageOption struct {
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+}
+
+// NewPageOption 创建一个分页参数，page 从 1 开始，pageSize 取值范围 25~500
+func NewPageOption(page, pageSize int) PageOption {
+	if pageSize < 25 || pageSize > 500 {
+		pageSize = 500
+	}
+	if page < 1 {
+		page = 1
+	}
+	return PageOption{Page: page, PageSize: pageSize}
+}
@@ -0,0 +1,35 @@
+package linode
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// RecordService 解析记录服务
+type RecordService struct{ *Client }
+
+// List 获取域名解析记录列表
+// https://techdocs.akamai.com/linode-api/reference/get-domain-records
+func (r *RecordService) List(ctx context.Context, page PageOption, domainID string) (*RecordListResponse, error) {
+	resp, err := r.client.R().
+		SetContext(ctx).
+		SetPathParam("domainId", domainID).
+		SetQueryParams(map[string]string{
+			"page":      strconv.Itoa(page.Page),
+			"page_size": strconv.Itoa(page.PageSize),
+		}).
+		SetResult(&RecordListResponse{}).
+		Get("/domains/{domainId}/records")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*RecordListResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *RecordListResponse")
+	}
+	return result, nil
+}
@@ -0,0 +1,41 @@
+package powerdns
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Client PowerDNS Authoritative 服务器 HTTP API 客户端
+type Client struct {
+	client *resty.Client
+
+	// Services
+	Zones *ZoneService
+}
+
+// DefaultServerID 默认服务器ID，PowerDNS Authoritative 仅支持 localhost
+const DefaultServerID = "localhost"
+
+// NewClient 初始化客户端，apiURL 为 webserver 地址，如 http://127.0.0.1:8081
+func NewClient(apiURL, apiKey, serverID string) (*Client, error) {
+	c := new(Client)
+	if apiURL == "" {
+		return c, errors.New("missing powerdns API url")
+	}
+	if apiKey == "" {
+		return c, errors.New("missing powerdns API key")
+	}
+	if serverID == "" {
+		serverID = DefaultServerID
+	}
+	c.client = resty.New().SetBaseURL(strings.TrimSuffix(apiURL, "/")+"/api/v1").
+		SetHeader("X-API-Key", apiKey).SetPathParam("server_id", serverID).
+		SetTimeout(10 * time.Second).SetRetryCount(3).SetRetryWaitTime(2 * time.Second)
+	// Initialize services
+	c.Zones = &ZoneService{c}
+
+	return c, nil
+}
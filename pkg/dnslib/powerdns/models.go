@@ -0,0 +1,33 @@
+package powerdns
+
+// Zone 区域，列表接口不返回 RRSets
+type Zone struct {
+	ID      string  `json:"id"`      // 区域ID，通常为以 . 结尾的区域名
+	Name    string  `json:"name"`    // 区域名，以 . 结尾
+	Kind    string  `json:"kind"`    // 区域类型 Native/Master/Slave/Producer/Consumer
+	Serial  int64   `json:"serial"`  // SOA 序列号
+	Account string  `json:"account"` // 区域所属账户，可选
+	RRSets  []RRSet `json:"rrsets"`  // 记录集，仅在获取单个区域时返回
+}
+
+// RRSet 记录集，一个记录集可以包含多个记录值
+type RRSet struct {
+	Name     string    `json:"name"`     // 记录名，以 . 结尾
+	Type     string    `json:"type"`     // 记录类型
+	TTL      int       `json:"ttl"`      // TTL
+	Records  []Record  `json:"records"`  // 记录值
+	Comments []Comment `json:"comments"` // 备注
+}
+
+// Record 记录值
+type Record struct {
+	Content  string `json:"content"`  // 记录值
+	Disabled bool   `json:"disabled"` // 是否已禁用
+}
+
+// Comment 记录集备注
+type Comment struct {
+	Content    string `json:"content"`     // 备注内容
+	Account    string `json:"account"`     // 备注作者
+	ModifiedAt int64  `json:"modified_at"` // 修改时间，Unix 时间戳
+}
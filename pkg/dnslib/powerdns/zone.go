@@ -0,0 +1,50 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+)
+
+// ZoneService 区域服务
+type ZoneService struct{ *Client }
+
+// List 获取服务器上的全部区域，接口不分页
+// https://doc.powerdns.com/authoritative/http-api/zone.html#get--servers-server_id-zones
+func (z *ZoneService) List(ctx context.Context) ([]Zone, error) {
+	resp, err := z.client.R().
+		SetContext(ctx).
+		SetResult(&[]Zone{}).
+		Get("/servers/{server_id}/zones")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*[]Zone)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *[]Zone")
+	}
+	return *result, nil
+}
+
+// Get 获取单个区域及其全部记录集
+// https://doc.powerdns.com/authoritative/http-api/zone.html#get--servers-server_id-zones-zone_id
+func (z *ZoneService) Get(ctx context.Context, zoneID string) (*Zone, error) {
+	resp, err := z.client.R().
+		SetContext(ctx).
+		SetPathParam("zone_id", zoneID).
+		SetResult(&Zone{}).
+		Get("/servers/{server_id}/zones/{zone_id}")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*Zone)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *Zone")
+	}
+	return result, nil
+}
@@ -0,0 +1,34 @@
+package vultr
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Client Vultr DNS 客户端
+type Client struct {
+	client *resty.Client
+
+	// Services
+	Domains *DomainService
+	Records *RecordService
+}
+
+var baseUrl = "https://api.vultr.com/v2"
+
+// NewClient 使用 API 密钥初始化客户端
+func NewClient(apiKey string) (*Client, error) {
+	c := new(Client)
+	if apiKey == "" {
+		return c, errors.New("missing vultr API key")
+	}
+	c.client = resty.New().SetBaseURL(baseUrl).SetAuthToken(apiKey).
+		SetTimeout(10 * time.Second).SetRetryCount(3).SetRetryWaitTime(2 * time.Second)
+	// Initialize services
+	c.Domains = &DomainService{c}
+	c.Records = &RecordService{c}
+
+	return c, nil
+}
@@ -0,0 +1,34 @@
+package vultr
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// DomainService 域名服务
+type DomainService struct{ *Client }
+
+// List 获取域名列表
+// https://www.vultr.com/api/#tag/dns/operation/list-dns-domains
+func (d *DomainService) List(ctx context.Context, page PageOption) (*DomainListResponse, error) {
+	req := d.client.R().
+		SetContext(ctx).
+		SetQueryParam("per_page", strconv.Itoa(page.PerPage)).
+		SetResult(&DomainListResponse{})
+	if page.Cursor != "" {
+		req.SetQueryParam("cursor", page.Cursor)
+	}
+	resp, err := req.Get("/domains")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*DomainListResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *DomainListResponse")
+	}
+	return result, nil
+}
@@ -0,0 +1,39 @@
+package vultr
+
+// DomainListResponse 域名列表响应
+type DomainListResponse struct {
+	Domains []Domain `json:"domains"`
+	Meta    Meta     `json:"meta"`
+}
+
+// Domain 域名
+type Domain struct {
+	Domain      string `json:"domain"`       // 域名
+	DateCreated string `json:"date_created"` // 创建时间
+	DNSSec      string `json:"dns_sec"`      // DNSSEC 状态 enabled/disabled
+}
+
+// RecordListResponse 解析记录列表响应
+type RecordListResponse struct {
+	Records []Record `json:"records"`
+	Meta    Meta     `json:"meta"`
+}
+
+// Record 解析记录
+type Record struct {
+	ID       string `json:"id"`       // 记录ID
+	Type     string `json:"type"`     // 记录类型
+	Name     string `json:"name"`     // 主机记录，空表示根域名
+	Data     string `json:"data"`     // 记录值
+	Priority int    `json:"priority"` // MX/SRV 优先级
+	TTL      int    `json:"ttl"`      // TTL
+}
+
+// Meta 列表元数据，没有下一页时 Links.Next 为空
+type Meta struct {
+	Total int `json:"total"` // 总数
+	Links struct {
+		Next string `json:"next"` // 下一页游标
+		Prev string `json:"prev"` // 上一页游标
+	} `json:"links"`
+}
@@ -0,0 +1,15 @@
+package vultr
+
+// PageOption 游标分页参数
+type PageOption struct {
+	Cursor  string `json:"cursor"`   // 游标，为空时获取第一页
+	PerPage int    `json:"per_page"` // 每页数量
+}
+
+// NewPageOption 创建一个分页参数，perPage 取值范围 1~500
+func NewPageOption(cursor string, perPage int) PageOption {
+	if perPage <= 0 || perPage > 500 {
+		perPage = 500
+	}
+	return PageOption{Cursor: cursor, PerPage: perPage}
+}
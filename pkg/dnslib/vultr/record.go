@@ -0,0 +1,35 @@
+package vultr
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// RecordService 解析记录服务
+type RecordService struct{ *Client }
+
+// List 获取域名解析记录列表
+// https://www.vultr.com/api/#tag/dns/operation/list-dns-domain-records
+func (r *RecordService) List(ctx context.Context, page PageOption, domain string) (*RecordListResponse, error) {
+	req := r.client.R().
+		SetContext(ctx).
+		SetPathParam("domain", domain).
+		SetQueryParam("per_page", strconv.Itoa(page.PerPage)).
+		SetResult(&RecordListResponse{})
+	if page.Cursor != "" {
+		req.SetQueryParam("cursor", page.Cursor)
+	}
+	resp, err := req.Get("/domains/{domain}/records")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode(), resp.String())
+	}
+	result, ok := resp.Result().(*RecordListResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast response to *RecordListResponse")
+	}
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// 采集任务类型，与 collected_at_timestamp_seconds 等指标的 data 标签取值一致
+const (
+	collectJobDomain = "domain"
+	collectJobRecord = "record"
+)
+
+// jobStatus 单个采集任务（域名或解析记录）的采集状态
+type jobStatus struct {
+	LastSuccess  time.Time     // 最近一次采集成功的时间
+	LastDuration time.Duration // 最近一次采集耗时
+	Success      bool          // 最近一次采集是否成功
+	Failures     uint64        // 采集失败的累计次数，包括接口报错、超时及创建客户端失败
+	APIErrors    uint64        // 服务商接口返回错误导致采集失败的累计次数，不含超时
+}
+
+// collectStatus 单个账号的采集状态，用于暴露采集健康度指标
+type collectStatus struct {
+	Domain  jobStatus // 域名采集任务的状态
+	Record  jobStatus // 解析记录采集任务的状态
+	Domains int       // 最近一次成功采集到的域名数
+	Records int       // 最近一次成功采集到的记录数
+}
+
+// job 获取指定采集任务的状态
+func (s *collectStatus) job(data string) *jobStatus {
+	if data == collectJobDomain {
+		return &s.Domain
+	}
+	return &s.Record
+}
+
+var (
+	statusMutex sync.RWMutex
+	statuses    = make(map[string]*collectStatus)
+)
+
+func statusKey(cloudProvider, cloudName string) string {
+	return cloudProvider + "_" + cloudName
+}
+
+// getStatus 获取账号采集状态，调用方需持有 statusMutex 写锁
+func getStatus(cloudProvider, cloudName string) *collectStatus {
+	key := statusKey(cloudProvider, cloudName)
+	status, ok := statuses[key]
+	if !ok {
+		status = &collectStatus{}
+		statuses[key] = status
+	}
+	return status
+}
+
+// markCollectSuccess 记录一次成功的采集，count 为采集到的域名数或记录数
+func markCollectSuccess(cloudProvider, cloudName, data string, start time.Time, count int) {
+	statusMutex.Lock()
+	defer statusMutex.Unlock()
+	status := getStatus(cloudProvider, cloudName)
+	job := status.job(data)
+	job.LastSuccess = time.Now()
+	job.LastDuration = time.Since(start)
+	job.Success = true
+	if data == collectJobDomain {
+		status.Domains = count
+	} else {
+		status.Records = count
+	}
+}
+
+// markCollectFailure 记录一次失败的采集，apiError 表示失败是否由服务商接口返回错误引起
+func markCollectFailure(cloudProvider, cloudName, data string, start time.Time, apiError bool) {
+	statusMutex.Lock()
+	defer statusMutex.Unlock()
+	job := getStatus(cloudProvider, cloudName).job(data)
+	job.LastDuration = time.Since(start)
+	job.Success = false
+	job.Failures++
+	if apiError {
+		job.APIErrors++
+	}
+}
+
+// isAPIError 判断采集失败是否由服务商接口返回错误引起，超时或取消不计入
+func isAPIError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled)
+}
+
+// loadStatus 获取账号采集状态的副本，账号从未采集过时返回 false
+func loadStatus(cloudProvider, cloudName string) (collectStatus, bool) {
+	statusMutex.RLock()
+	defer statusMutex.RUnlock()
+	status, ok := statuses[statusKey(cloudProvider, cloudName)]
+	if !ok {
+		return collectStatus{}, false
+	}
+	return *status, true
+}
+
+// removeStatus 删除账号采集状态，用于账号从配置中移除时
+func removeStatus(cloudProvider, cloudName string) {
+	statusMutex.Lock()
+	defer statusMutex.Unlock()
+	delete(statuses, statusKey(cloudProvider, cloudName))
+}
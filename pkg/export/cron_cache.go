@@ -1,9 +1,12 @@
 package export
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
 	"github.com/weppos/publicsuffix-go/publicsuffix"
@@ -13,160 +16,292 @@ import (
 	"github.com/robfig/cron/v3"
 )
 
-// InitCron 初始化定时任务
+// defaultCollectTimeout 单个账号一次采集的默认超时时间，需小于采集周期
+const defaultCollectTimeout = 4 * time.Minute
+
+// ready 所有账号是否已完成首次采集
+var ready atomic.Bool
+
+// Ready 所有账号是否已完成首次域名及解析记录采集，采集失败也视为已完成
+func Ready() bool {
+	return ready.Load()
+}
+
+// InitCron 初始化定时任务，首次采集在后台执行，不阻塞 HTTP 服务启动
 func InitCron() {
-	c := cron.New(cron.WithSeconds())
-	// 域名采集：每5分钟执行一次
-	_, _ = c.AddFunc("0 */5 * * * *", func() {
-		loading()
-	})
+	// 上一轮任务未结束时跳过本轮，避免卡住的采集不断堆积 goroutine
+	c := cron.New(cron.WithSeconds(), cron.WithChain(cron.SkipIfStillRunning(cron.DefaultLogger)))
+	jobs = newScheduler(c)
+	jobs.sync(public.GetConfig())
+
+	// 从快照文件恢复了所有账号的数据时，无需等待首次采集即可就绪
+	if restored(public.GetConfig()) {
+		ready.Store(true)
+	}
 
-	// 证书采集：每小时执行一次
-	_, _ = c.AddFunc("0 0 */1 * * *", func() {
+	go func() {
+		// 启动时先执行域名采集，完成后再执行证书采集
+		logger.Info("开始初始化数据采集...")
+		loading() // 先执行域名采集
+		ready.Store(true)
+		logger.Info("域名数据采集完成，开始证书数据采集...")
+
+		// 域名采集完成后立即执行证书采集
 		loadingCert()
 		loadingCustomRecordCert()
-	})
+		logger.Info("初始化数据采集完成")
 
-	// 启动时先执行域名采集，完成后再执行证书采集
-	logger.Info("开始初始化数据采集...")
-	loading() // 先执行域名采集
-	logger.Info("域名数据采集完成，开始证书数据采集...")
-
-	// 域名采集完成后立即执行证书采集
-	loadingCert()
-	loadingCustomRecordCert()
-	logger.Info("初始化数据采集完成")
+		// 首次采集完成后再启动定时任务，避免与首次采集重叠
+		c.Start()
+	}()
+}
 
-	c.Start()
+// restored 所有账号的解析记录是否均已从快照文件恢复
+func restored(config *public.Configuration) bool {
+	ok := true
+	eachAccount(config, func(cloudProvider string, account map[string]string) {
+		if _, err := public.Cache.Get(public.RecordList + "_" + cloudProvider + "_" + account["name"]); err != nil {
+			ok = false
+		}
+	})
+	return ok
 }
 
 func loading() {
 	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for cloudProvider, accounts := range public.Config.CloudProviders {
-		for _, cloudAccount := range accounts.Accounts {
-			wg.Add(1)
-			go func(cloudProvider, cloudName string, account map[string]string) {
-				defer wg.Done()
-				domainListCacheKey := public.DomainList + "_" + cloudProvider + "_" + cloudName
-				dnsProvider, err := provider.Factory.Create(cloudProvider, account)
-				if err != nil {
-					logger.Error(fmt.Sprintf("[ %s ] create provider failed: %v", domainListCacheKey, err))
-					return
-				}
-				domains, err := dnsProvider.ListDomains()
-				if err != nil {
-					logger.Error(fmt.Sprintf("[ %s ] list domains failed: %v", domainListCacheKey, err))
-					return
-				}
-
-				mu.Lock()
-				value, err := json.Marshal(domains)
-				if err != nil {
-					logger.Error(fmt.Sprintf("[ %s ] marshal domain list failed: %v", domainListCacheKey, err))
-				}
-				if err := public.Cache.Set(domainListCacheKey, value); err != nil {
-					logger.Error(fmt.Sprintf("[ %s ] cache domain list failed: %v", domainListCacheKey, err))
-				}
-				mu.Unlock()
-
-				recordListCacheKey := public.RecordList + "_" + cloudProvider + "_" + cloudName
-				records, err := dnsProvider.ListRecords()
-				if err != nil {
-					logger.Error(fmt.Sprintf("[ %s ] list records failed: %v", recordListCacheKey, err))
-					return
-				}
-				mu.Lock()
-				value, err = json.Marshal(records)
-				if err != nil {
-					logger.Error(fmt.Sprintf("[ %s ] marshal record list failed: %v", recordListCacheKey, err))
-				} else {
-					if err := public.Cache.Set(recordListCacheKey, value); err != nil {
-						logger.Error(fmt.Sprintf("[ %s ] cache record list failed: %v", recordListCacheKey, err))
-					} else {
-						logger.Info(fmt.Sprintf("[ %s ] successfully cached %d records", recordListCacheKey, len(records)))
-					}
-				}
-				mu.Unlock()
-			}(cloudProvider, cloudAccount["name"], cloudAccount)
+	eachAccount(public.GetConfig(), func(cloudProvider string, account map[string]string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loadingAccount(cloudProvider, account)
+		}()
+	})
+	wg.Wait()
+}
+
+// eachAccount 遍历配置中的所有账号
+func eachAccount(config *public.Configuration, fn func(cloudProvider string, account map[string]string)) {
+	for cloudProvider, accounts := range config.CloudProviders {
+		for _, account := range accounts.Accounts {
+			fn(cloudProvider, account)
 		}
 	}
-	wg.Wait()
 }
 
-func loadingCert() {
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for cloudProvider, accounts := range public.Config.CloudProviders {
-		for _, cloudAccount := range accounts.Accounts {
-			wg.Add(1)
-			go func(cloudProvider, cloudName string, account map[string]string) {
-				defer wg.Done()
-				recordListCacheKey := public.RecordList + "_" + cloudProvider + "_" + cloudName
-				var records []provider.Record
-				rst2, err := public.Cache.Get(recordListCacheKey)
-				if err != nil {
-					logger.Error(fmt.Sprintf("[ %s ] get record list from cache failed: %v", recordListCacheKey, err))
-					return // 缓存获取失败时直接返回
-				}
-
-				err = json.Unmarshal(rst2, &records)
-				if err != nil {
-					logger.Error(fmt.Sprintf("[ %s ] json.Unmarshal error: %v", recordListCacheKey, err))
-					return // JSON解析失败时直接返回
-				}
-
-				if len(records) == 0 {
-					logger.Info(fmt.Sprintf("[ %s ] no records found in cache, skipping cert collection", recordListCacheKey))
-					return // 没有记录时直接返回
-				}
-
-				logger.Info(fmt.Sprintf("[ %s ] found %d records in cache, starting cert collection", recordListCacheKey, len(records)))
-				var recordCertReq []provider.GetRecordCertReq
-				for _, v := range getNewRecord(records) {
-					recordCertReq = append(recordCertReq, provider.GetRecordCertReq{
-						CloudProvider: v.CloudProvider,
-						CloudName:     v.CloudName,
-						DomainName:    v.DomainName,
-						DomainType:    v.DomainType, // 新增：传递域名类型
-						FullRecord:    v.FullRecord,
-						RecordValue:   v.RecordValue,
-						RecordID:      v.RecordID,
-					})
-				}
-				recordCerts, err := GetMultipleCertInfo(recordCertReq)
-				if err != nil {
-					logger.Error(fmt.Sprintf("[ %s ] get record cert info failed: %v", recordListCacheKey, err))
-					return
-				}
-
-				mu.Lock()
-				recordCertInfoCacheKey := public.RecordCertInfo + "_" + cloudProvider + "_" + cloudName
-				value, err := json.Marshal(recordCerts)
-				if err != nil {
-					logger.Error(fmt.Sprintf("[ %s ] marshal cert list failed: %v", recordCertInfoCacheKey, err))
-				} else {
-					if err := public.CertCache.Set(recordCertInfoCacheKey, value); err != nil {
-						logger.Error(fmt.Sprintf("[ %s ] cache cert list failed: %v", recordCertInfoCacheKey, err))
-					} else {
-						logger.Info(fmt.Sprintf("[ %s ] successfully cached %d cert records", recordCertInfoCacheKey, len(recordCerts)))
-					}
-				}
-				mu.Unlock()
-			}(cloudProvider, cloudAccount["name"], cloudAccount)
+// loadingAccount 采集单个账号的域名与解析记录
+func loadingAccount(cloudProvider string, account map[string]string) {
+	if _, ok := loadingAccountDomains(cloudProvider, account); ok {
+		loadingAccountRecords(cloudProvider, account)
+	}
+}
+
+// loadingAccountDomains 采集单个账号的域名列表并写入缓存
+func loadingAccountDomains(cloudProvider string, account map[string]string) ([]provider.Domain, bool) {
+	cloudName := account["name"]
+	start := time.Now()
+	domainListCacheKey := public.DomainList + "_" + cloudProvider + "_" + cloudName
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout(account))
+	defer cancel()
+	fail := func(apiError bool) {
+		storeAccountData(cloudProvider, cloudName, account, func() {
+			public.Cache.MarkStale(domainListCacheKey)
+			markCollectFailure(cloudProvider, cloudName, collectJobDomain, start, apiError)
+		})
+	}
+	dnsProvider, err := provider.Factory.Create(cloudProvider, account)
+	if err != nil {
+		logger.Error(fmt.Sprintf("[ %s ] create provider failed: %v", domainListCacheKey, err))
+		fail(false)
+		return nil, false
+	}
+	domains, err := dnsProvider.ListDomains(ctx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("[ %s ] list domains failed: %v", domainListCacheKey, err))
+		fail(isAPIError(ctx, err))
+		return nil, false
+	}
+
+	value, err := json.Marshal(domains)
+	if err != nil {
+		logger.Error(fmt.Sprintf("[ %s ] marshal domain list failed: %v", domainListCacheKey, err))
+	}
+	stored := storeAccountData(cloudProvider, cloudName, account, func() {
+		if err := public.Cache.Set(domainListCacheKey, value); err != nil {
+			logger.Error(fmt.Sprintf("[ %s ] cache domain list failed: %v", domainListCacheKey, err))
+		}
+		markCollectSuccess(cloudProvider, cloudName, collectJobDomain, start, len(domains))
+	})
+	if !stored {
+		logger.Info(fmt.Sprintf("[ %s ] account removed or changed during collection, discard the domain list", domainListCacheKey))
+		return nil, false
+	}
+	return domains, true
+}
+
+// loadingAccountRecords 根据缓存中的域名列表采集单个账号的解析记录，缓存中没有域名列表时先采集域名
+func loadingAccountRecords(cloudProvider string, account map[string]string) {
+	cloudName := account["name"]
+	domainListCacheKey := public.DomainList + "_" + cloudProvider + "_" + cloudName
+	var domains []provider.Domain
+	recordListCacheKey := public.RecordList + "_" + cloudProvider + "_" + cloudName
+	rst, err := public.Cache.Get(domainListCacheKey)
+	if err == nil {
+		err = json.Unmarshal(rst, &domains)
+	}
+	if err != nil {
+		var ok bool
+		if domains, ok = loadingAccountDomains(cloudProvider, account); !ok {
+			storeAccountData(cloudProvider, cloudName, account, func() {
+				public.Cache.MarkStale(recordListCacheKey)
+			})
+			return
 		}
 	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout(account))
+	defer cancel()
+	fail := func(apiError bool) {
+		storeAccountData(cloudProvider, cloudName, account, func() {
+			public.Cache.MarkStale(recordListCacheKey)
+			markCollectFailure(cloudProvider, cloudName, collectJobRecord, start, apiError)
+		})
+	}
+	dnsProvider, err := provider.Factory.Create(cloudProvider, account)
+	if err != nil {
+		logger.Error(fmt.Sprintf("[ %s ] create provider failed: %v", recordListCacheKey, err))
+		fail(false)
+		return
+	}
+	records, err := dnsProvider.ListRecords(ctx, domains)
+	if err != nil {
+		logger.Error(fmt.Sprintf("[ %s ] list records failed: %v", recordListCacheKey, err))
+		fail(isAPIError(ctx, err))
+		return
+	}
+	value, err := json.Marshal(records)
+	if err != nil {
+		logger.Error(fmt.Sprintf("[ %s ] marshal record list failed: %v", recordListCacheKey, err))
+		fail(false)
+		return
+	}
+	stored := storeAccountData(cloudProvider, cloudName, account, func() {
+		if err = public.Cache.Set(recordListCacheKey, value); err != nil {
+			logger.Error(fmt.Sprintf("[ %s ] cache record list failed: %v", recordListCacheKey, err))
+			public.Cache.MarkStale(recordListCacheKey)
+			markCollectFailure(cloudProvider, cloudName, collectJobRecord, start, false)
+			return
+		}
+		markCollectSuccess(cloudProvider, cloudName, collectJobRecord, start, len(records))
+	})
+	if !stored {
+		logger.Info(fmt.Sprintf("[ %s ] account removed or changed during collection, discard the record list", recordListCacheKey))
+		return
+	}
+	if err == nil {
+		logger.Info(fmt.Sprintf("[ %s ] successfully cached %d records", recordListCacheKey, len(records)))
+	}
+}
+
+// collectTimeout 获取账号的采集超时时间，可在账号配置中通过 timeout 字段覆盖，如 timeout: "2m"
+func collectTimeout(account map[string]string) time.Duration {
+	value, ok := account["timeout"]
+	if !ok || value == "" {
+		return defaultCollectTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		logger.Warning(fmt.Sprintf("[ %s ] invalid timeout %q, use default %s", account["name"], value, defaultCollectTimeout))
+		return defaultCollectTimeout
+	}
+	return timeout
+}
+
+func loadingCert() {
+	var wg sync.WaitGroup
+	eachAccount(public.GetConfig(), func(cloudProvider string, account map[string]string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loadingAccountCert(cloudProvider, account["name"])
+		}()
+	})
 	wg.Wait()
 }
 
+// loadingAccountCert 根据缓存中的解析记录采集单个账号的证书信息
+func loadingAccountCert(cloudProvider, cloudName string) {
+	recordListCacheKey := public.RecordList + "_" + cloudProvider + "_" + cloudName
+	recordCertInfoCacheKey := public.RecordCertInfo + "_" + cloudProvider + "_" + cloudName
+	var records []provider.Record
+	rst2, err := public.Cache.Get(recordListCacheKey)
+	if err != nil {
+		logger.Error(fmt.Sprintf("[ %s ] get record list from cache failed: %v", recordListCacheKey, err))
+		storeAccountData(cloudProvider, cloudName, nil, func() {
+			public.CertCache.MarkStale(recordCertInfoCacheKey)
+		})
+		return // 缓存获取失败时直接返回
+	}
+
+	err = json.Unmarshal(rst2, &records)
+	if err != nil {
+		logger.Error(fmt.Sprintf("[ %s ] json.Unmarshal error: %v", recordListCacheKey, err))
+		return // JSON解析失败时直接返回
+	}
+
+	if len(records) == 0 {
+		logger.Info(fmt.Sprintf("[ %s ] no records found in cache, skipping cert collection", recordListCacheKey))
+		return // 没有记录时直接返回
+	}
+
+	logger.Info(fmt.Sprintf("[ %s ] found %d records in cache, starting cert collection", recordListCacheKey, len(records)))
+	var recordCertReq []provider.GetRecordCertReq
+	for _, v := range getNewRecord(records) {
+		recordCertReq = append(recordCertReq, provider.GetRecordCertReq{
+			CloudProvider: v.CloudProvider,
+			CloudName:     v.CloudName,
+			DomainName:    v.DomainName,
+			DomainType:    v.DomainType, // 新增：传递域名类型
+			FullRecord:    v.FullRecord,
+			RecordValue:   v.RecordValue,
+			RecordID:      v.RecordID,
+		})
+	}
+	recordCerts, err := GetMultipleCertInfo(recordCertReq)
+	if err != nil {
+		logger.Error(fmt.Sprintf("[ %s ] get record cert info failed: %v", recordListCacheKey, err))
+		storeAccountData(cloudProvider, cloudName, nil, func() {
+			public.CertCache.MarkStale(recordCertInfoCacheKey)
+		})
+		return
+	}
+
+	value, err := json.Marshal(recordCerts)
+	if err != nil {
+		logger.Error(fmt.Sprintf("[ %s ] marshal cert list failed: %v", recordCertInfoCacheKey, err))
+		return
+	}
+	stored := storeAccountData(cloudProvider, cloudName, nil, func() {
+		err = public.CertCache.Set(recordCertInfoCacheKey, value)
+	})
+	if !stored {
+		logger.Info(fmt.Sprintf("[ %s ] account removed during collection, discard the cert list", recordCertInfoCacheKey))
+		return
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("[ %s ] cache cert list failed: %v", recordCertInfoCacheKey, err))
+		return
+	}
+	logger.Info(fmt.Sprintf("[ %s ] successfully cached %d cert records", recordCertInfoCacheKey, len(recordCerts)))
+}
+
 func loadingCustomRecordCert() {
-	if len(public.Config.CustomRecords) == 0 {
+	customRecords := public.GetConfig().CustomRecords
+	if len(customRecords) == 0 {
 		return
 	}
 	var records []provider.Record
-	for _, v := range public.Config.CustomRecords {
+	for _, v := range customRecords {
 		domainName, err := publicsuffix.Domain(v)
 		if err != nil {
 			logger.Error(fmt.Sprintf("[ custom ] get domain failed: %v", err))
@@ -178,7 +313,7 @@ func loadingCustomRecordCert() {
 			DomainType:    "public", // 自定义记录默认为公网类型
 			FullRecord:    v,
 			RecordValue:   v,
-			RecordID:      public.GetID(),
+			RecordID:      public.GetRecordID(public.CustomRecords, v),
 			RecordType:    "CNAME", // 默认指定为CNAME记录,这两条记录为了通过检测
 			RecordStatus:  "enable",
 		})
@@ -195,12 +330,13 @@ func loadingCustomRecordCert() {
 			RecordID:      v.RecordID,
 		})
 	}
+	recordCertInfoCacheKey := public.RecordCertInfo + "_" + public.CustomRecords
 	recordCerts, err := GetMultipleCertInfo(recordCertReq)
 	if err != nil {
 		logger.Error(fmt.Sprintf("[ custom ] get record cert info failed: %v", err))
+		public.CertCache.MarkStale(recordCertInfoCacheKey)
 		return
 	}
-	recordCertInfoCacheKey := public.RecordCertInfo + "_" + public.CustomRecords
 	value, err := json.Marshal(recordCerts)
 	if err != nil {
 		logger.Error(fmt.Sprintf("[ %s ] marshal domain list failed: %v", recordCertInfoCacheKey, err))
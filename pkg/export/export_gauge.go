@@ -42,6 +42,7 @@ func NewMetrics(namespace string) *Metrics {
 					"domain_type", // 新增：域名类型标签
 					"domain_remark",
 					"domain_status",
+					"domain_vpcs",
 					"created_date",
 					"expiry_date",
 				}),
@@ -59,6 +60,14 @@ func NewMetrics(namespace string) *Metrics {
 					"record_value",
 					"record_ttl",
 					"record_weight",
+					"record_line",
+					"record_proxied",
+					"record_alias",
+					"set_identifier",
+					"routing_policy",
+					"record_region",
+					"geo_location",
+					"health_check_id",
 					"record_status",
 					"record_remark",
 					"update_time",
@@ -85,6 +94,42 @@ func NewMetrics(namespace string) *Metrics {
 					"cert_matched",
 					"error_msg",
 				}),
+			public.CollectLastSuccessTimestamp: newGlobalMetric(namespace,
+				public.CollectLastSuccessTimestamp,
+				"Unix timestamp of the last successful collection",
+				[]string{"cloud_provider", "cloud_name", "data"}),
+			public.CollectDuration: newGlobalMetric(namespace,
+				public.CollectDuration,
+				"Duration of the last collection in seconds",
+				[]string{"cloud_provider", "cloud_name", "data"}),
+			public.CollectSuccess: newGlobalMetric(namespace,
+				public.CollectSuccess,
+				"Whether the last collection succeeded (1) or failed (0)",
+				[]string{"cloud_provider", "cloud_name", "data"}),
+			public.CollectDomains: newGlobalMetric(namespace,
+				public.CollectDomains,
+				"Number of domains collected by the last successful collection",
+				[]string{"cloud_provider", "cloud_name"}),
+			public.CollectRecords: newGlobalMetric(namespace,
+				public.CollectRecords,
+				"Number of records collected by the last successful collection",
+				[]string{"cloud_provider", "cloud_name"}),
+			public.CollectFailures: newGlobalMetric(namespace,
+				public.CollectFailures,
+				"Total number of failed collections, including provider API errors and timeouts",
+				[]string{"cloud_provider", "cloud_name", "data"}),
+			public.CollectAPIErrors: newGlobalMetric(namespace,
+				public.CollectAPIErrors,
+				"Total number of collections failed by a provider API error, excluding timeouts",
+				[]string{"cloud_provider", "cloud_name", "data"}),
+			public.CollectedAtTimestamp: newGlobalMetric(namespace,
+				public.CollectedAtTimestamp,
+				"Unix timestamp when the currently served data was collected",
+				[]string{"cloud_provider", "cloud_name", "data"}),
+			public.DataStale: newGlobalMetric(namespace,
+				public.DataStale,
+				"Whether the last refresh failed and the previous data is served (1) or not (0)",
+				[]string{"cloud_provider", "cloud_name", "data"}),
 		},
 	}
 }
@@ -101,9 +146,13 @@ func (c *Metrics) Collect(ch chan<- prometheus.Metric) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	for cloudProvider, accounts := range public.Config.CloudProviders {
+	config := public.GetConfig()
+	for cloudProvider, accounts := range config.CloudProviders {
 		for _, cloudAccount := range accounts.Accounts {
 			cloudName := cloudAccount["name"]
+			// collect health metrics
+			c.collectHealth(ch, cloudProvider, cloudName)
+			c.collectFreshness(ch, cloudProvider, cloudName)
 			// get domain list from cache
 			domainListCacheKey := public.DomainList + "_" + cloudProvider + "_" + cloudName
 			var domains []provider.Domain
@@ -119,7 +168,7 @@ func (c *Metrics) Collect(ch chan<- prometheus.Metric) {
 			}
 			for _, v := range domains {
 				ch <- prometheus.MustNewConstMetric(
-					c.metrics[public.DomainList], prometheus.GaugeValue, float64(v.DaysUntilExpiry), v.CloudProvider, v.CloudName, v.DomainID, v.DomainName, v.DomainType, v.DomainRemark, v.DomainStatus, v.CreatedDate, v.ExpiryDate)
+					c.metrics[public.DomainList], prometheus.GaugeValue, float64(v.DaysUntilExpiry), v.CloudProvider, v.CloudName, v.DomainID, v.DomainName, v.DomainType, v.DomainRemark, v.DomainStatus, v.DomainVPCs, v.CreatedDate, v.ExpiryDate)
 			}
 			// get record list from cache
 			recordListCacheKey := public.RecordList + "_" + cloudProvider + "_" + cloudName
@@ -139,7 +188,7 @@ func (c *Metrics) Collect(ch chan<- prometheus.Metric) {
 					continue
 				}
 				ch <- prometheus.MustNewConstMetric(
-					c.metrics[public.RecordList], prometheus.GaugeValue, 1, v.CloudProvider, v.CloudName, v.DomainName, v.DomainType, v.RecordID, v.RecordType, v.RecordName, v.RecordValue, v.RecordTTL, v.RecordWeight, v.RecordStatus, v.RecordRemark, v.UpdateTime, v.FullRecord)
+					c.metrics[public.RecordList], prometheus.GaugeValue, 1, v.CloudProvider, v.CloudName, v.DomainName, v.DomainType, v.RecordID, v.RecordType, v.RecordName, v.RecordValue, v.RecordTTL, v.RecordWeight, v.RecordLine, v.RecordProxied, v.RecordAlias, v.SetIdentifier, v.RoutingPolicy, v.RecordRegion, v.GeoLocation, v.HealthCheckID, v.RecordStatus, v.RecordRemark, v.UpdateTime, v.FullRecord)
 			}
 			// get record cert info list from cache
 			recordCertInfoCacheKey := public.RecordCertInfo + "_" + cloudProvider + "_" + cloudName
@@ -164,8 +213,9 @@ func (c *Metrics) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	// get custom record cert info list from cache
-	if len(public.Config.CustomRecords) != 0 {
+	if len(config.CustomRecords) != 0 {
 		recordCertInfoCacheKey := public.RecordCertInfo + "_" + public.CustomRecords
+		c.collectSnapshot(ch, public.CertCache, recordCertInfoCacheKey, public.CustomRecords, public.CustomRecords, "cert")
 		var recordCerts []provider.RecordCert
 		recordCertInfoCacheValue, err := public.CertCache.Get(recordCertInfoCacheKey)
 		if err != nil {
@@ -182,3 +232,52 @@ func (c *Metrics) Collect(ch chan<- prometheus.Metric) {
 		}
 	}
 }
+
+// collectHealth 输出账号的采集健康度指标，账号尚未完成过采集时不输出
+func (c *Metrics) collectHealth(ch chan<- prometheus.Metric, cloudProvider, cloudName string) {
+	status, ok := loadStatus(cloudProvider, cloudName)
+	if !ok {
+		return
+	}
+	for _, data := range []string{collectJobDomain, collectJobRecord} {
+		job := status.job(data)
+		// 任务尚未执行过（如解析记录任务等待域名采集）时不输出
+		if job.LastDuration == 0 && job.Failures == 0 {
+			continue
+		}
+		if !job.LastSuccess.IsZero() {
+			ch <- prometheus.MustNewConstMetric(c.metrics[public.CollectLastSuccessTimestamp], prometheus.GaugeValue, float64(job.LastSuccess.Unix()), cloudProvider, cloudName, data)
+		}
+		success := 0.0
+		if job.Success {
+			success = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.metrics[public.CollectDuration], prometheus.GaugeValue, job.LastDuration.Seconds(), cloudProvider, cloudName, data)
+		ch <- prometheus.MustNewConstMetric(c.metrics[public.CollectSuccess], prometheus.GaugeValue, success, cloudProvider, cloudName, data)
+		ch <- prometheus.MustNewConstMetric(c.metrics[public.CollectFailures], prometheus.CounterValue, float64(job.Failures), cloudProvider, cloudName, data)
+		ch <- prometheus.MustNewConstMetric(c.metrics[public.CollectAPIErrors], prometheus.CounterValue, float64(job.APIErrors), cloudProvider, cloudName, data)
+	}
+	ch <- prometheus.MustNewConstMetric(c.metrics[public.CollectDomains], prometheus.GaugeValue, float64(status.Domains), cloudProvider, cloudName)
+	ch <- prometheus.MustNewConstMetric(c.metrics[public.CollectRecords], prometheus.GaugeValue, float64(status.Records), cloudProvider, cloudName)
+}
+
+// collectFreshness 输出账号各类数据的采集时间与是否过期指标
+func (c *Metrics) collectFreshness(ch chan<- prometheus.Metric, cloudProvider, cloudName string) {
+	c.collectSnapshot(ch, public.Cache, public.DomainList+"_"+cloudProvider+"_"+cloudName, cloudProvider, cloudName, "domain")
+	c.collectSnapshot(ch, public.Cache, public.RecordList+"_"+cloudProvider+"_"+cloudName, cloudProvider, cloudName, "record")
+	c.collectSnapshot(ch, public.CertCache, public.RecordCertInfo+"_"+cloudProvider+"_"+cloudName, cloudProvider, cloudName, "cert")
+}
+
+// collectSnapshot 输出单份缓存数据的采集时间与是否过期指标，缓存中没有数据时不输出
+func (c *Metrics) collectSnapshot(ch chan<- prometheus.Metric, store *public.Store, key, cloudProvider, cloudName, data string) {
+	snapshot, ok := store.Snapshot(key)
+	if !ok {
+		return
+	}
+	stale := 0.0
+	if snapshot.Stale {
+		stale = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.metrics[public.CollectedAtTimestamp], prometheus.GaugeValue, float64(snapshot.CollectedAt.Unix()), cloudProvider, cloudName, data)
+	ch <- prometheus.MustNewConstMetric(c.metrics[public.DataStale], prometheus.GaugeValue, stale, cloudProvider, cloudName, data)
+}
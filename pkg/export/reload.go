@@ -0,0 +1,204 @@
+package export
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/provider"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	"github.com/fsnotify/fsnotify"
+)
+
+var reloadMutex sync.Mutex
+
+// accountDataMutex 保护账号数据的写入与删除：采集任务写入时持有读锁，删除账号数据时持有写锁
+var accountDataMutex sync.RWMutex
+
+// configAccount 配置中的单个账号
+type configAccount struct {
+	cloudProvider string
+	account       map[string]string
+}
+
+// Reload 重新加载配置文件
+// 新配置校验通过后整体替换当前配置，仅对新增或变更的账号立即重新采集，
+// 未变化账号的缓存数据保持不变，已删除账号的缓存数据会被清理
+func Reload() error {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	newConfig, err := public.LoadConfig(public.ConfigFile)
+	if err != nil {
+		return err
+	}
+	if err := validateProviders(newConfig); err != nil {
+		return err
+	}
+
+	oldConfig := public.GetConfig()
+	oldAccounts := configAccounts(oldConfig)
+	newAccounts := configAccounts(newConfig)
+	public.SetConfig(newConfig)
+	public.UpdateCacheMaxAge(newConfig)
+	if jobs != nil {
+		jobs.sync(newConfig)
+	}
+
+	var added, updated, removed int
+	for key, acc := range newAccounts {
+		old, exists := oldAccounts[key]
+		if exists && maps.Equal(old.account, acc.account) {
+			continue
+		}
+		if exists {
+			updated++
+			provider.ReleaseAccount(acc.cloudProvider, acc.account["name"])
+		} else {
+			added++
+		}
+		go func(acc configAccount) {
+			loadingAccount(acc.cloudProvider, acc.account)
+			loadingAccountCert(acc.cloudProvider, acc.account["name"])
+		}(acc)
+	}
+	for key, acc := range oldAccounts {
+		if _, exists := newAccounts[key]; !exists {
+			removed++
+			removeAccountData(acc.cloudProvider, acc.account["name"])
+		}
+	}
+	if !slices.Equal(oldConfig.CustomRecords, newConfig.CustomRecords) {
+		go loadingCustomRecordCert()
+	}
+
+	logger.Info(fmt.Sprintf("配置已重新加载，新增账号 %d 个，变更账号 %d 个，删除账号 %d 个", added, updated, removed))
+	return nil
+}
+
+// validateProviders 校验配置中的服务商是否均已注册
+func validateProviders(config *public.Configuration) error {
+	var err error
+	eachAccount(config, func(cloudProvider string, account map[string]string) {
+		if err != nil {
+			return
+		}
+		_, err = provider.Factory.Create(cloudProvider, account)
+	})
+	return err
+}
+
+// configAccounts 按账号唯一标识索引配置中的账号
+func configAccounts(config *public.Configuration) map[string]configAccount {
+	accounts := make(map[string]configAccount)
+	eachAccount(config, func(cloudProvider string, account map[string]string) {
+		accounts[public.AccountKey(cloudProvider, account["name"])] = configAccount{
+			cloudProvider: cloudProvider,
+			account:       account,
+		}
+	})
+	return accounts
+}
+
+// storeAccountData 账号仍在当前配置中时执行 fn 写入账号的缓存数据与采集状态，返回是否已写入
+// 账号被删除或变更后，仍在执行的旧采集任务不再写入，避免已删除账号的数据被写回缓存及快照文件，
+// 或旧配置的采集结果覆盖新配置的采集结果；account 为 nil 时仅按账号名称判断
+func storeAccountData(cloudProvider, cloudName string, account map[string]string, fn func()) bool {
+	accountDataMutex.RLock()
+	defer accountDataMutex.RUnlock()
+	if !accountActive(public.GetConfig(), cloudProvider, cloudName, account) {
+		return false
+	}
+	fn()
+	return true
+}
+
+// accountActive 账号是否在配置中，account 不为 nil 时还要求账号配置完全一致
+func accountActive(config *public.Configuration, cloudProvider, cloudName string, account map[string]string) bool {
+	accounts, ok := config.CloudProviders[cloudProvider]
+	if !ok {
+		return false
+	}
+	for _, acc := range accounts.Accounts {
+		if acc["name"] == cloudName {
+			return account == nil || maps.Equal(acc, account)
+		}
+	}
+	return false
+}
+
+// removeAccountData 清理已删除账号的缓存数据与采集状态
+// 调用前需已切换到新配置，持有写锁保证正在写入的采集任务完成后再清理，此后的写入会因账号不在配置中而被丢弃
+func removeAccountData(cloudProvider, cloudName string) {
+	accountDataMutex.Lock()
+	defer accountDataMutex.Unlock()
+	_ = public.Cache.Delete(public.DomainList + "_" + cloudProvider + "_" + cloudName)
+	_ = public.Cache.Delete(public.RecordList + "_" + cloudProvider + "_" + cloudName)
+	_ = public.CertCache.Delete(public.RecordCertInfo + "_" + cloudProvider + "_" + cloudName)
+	removeStatus(cloudProvider, cloudName)
+	provider.ReleaseAccount(cloudProvider, cloudName)
+}
+
+// WatchConfig 监听配置文件变化，内容变化时自动重新加载
+// 监听的是配置文件所在目录，以兼容编辑器的原子替换及 Kubernetes ConfigMap 的软链接切换
+func WatchConfig(configFile string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(configFile)); err != nil {
+		watcher.Close()
+		return err
+	}
+	go func() {
+		defer watcher.Close()
+		lastSum := fileSum(configFile)
+		// 合并短时间内的多次文件事件
+		debounce := time.NewTimer(time.Hour)
+		debounce.Stop()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				name := filepath.Base(event.Name)
+				if name == filepath.Base(configFile) || strings.HasPrefix(name, "..") {
+					debounce.Reset(time.Second)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Error(fmt.Sprintf("watch config file failed: %v", err))
+			case <-debounce.C:
+				sum := fileSum(configFile)
+				if sum == lastSum {
+					continue
+				}
+				lastSum = sum
+				logger.Info("检测到配置文件变化，重新加载配置...")
+				if err := Reload(); err != nil {
+					logger.Error(fmt.Sprintf("reload config failed, keep the current config: %v", err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// fileSum 计算文件内容摘要，文件不可读时返回零值
+func fileSum(file string) [sha256.Size]byte {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return [sha256.Size]byte{}
+	}
+	return sha256.Sum256(data)
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	"github.com/robfig/cron/v3"
+)
+
+// jobs 全局定时任务调度器，在 InitCron 中初始化
+var jobs *scheduler
+
+// scheduledAccount 单个账号已注册的定时任务
+type scheduledAccount struct {
+	account  map[string]string
+	schedule public.AccountSchedule
+	entries  []cron.EntryID
+}
+
+// job 单个定时任务
+type job struct {
+	spec string // cron 表达式
+	fn   func()
+}
+
+// scheduler 按账号管理定时任务，每个账号的域名、解析记录、证书采集分别为独立的任务
+type scheduler struct {
+	mu       sync.Mutex
+	cron     *cron.Cron
+	accounts map[string]*scheduledAccount
+}
+
+func newScheduler(c *cron.Cron) *scheduler {
+	return &scheduler{
+		cron:     c,
+		accounts: make(map[string]*scheduledAccount),
+	}
+}
+
+// sync 根据配置同步定时任务，仅重建采集周期或账号配置发生变化的任务，并移除已删除账号的任务
+func (s *scheduler) sync(config *public.Configuration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := make(map[string]bool)
+	eachAccount(config, func(cloudProvider string, account map[string]string) {
+		key := public.AccountKey(cloudProvider, account["name"])
+		current[key] = true
+		schedule, err := config.ScheduleOf(account)
+		if err != nil {
+			// 配置加载时已校验，这里仅做兜底
+			logger.Error(fmt.Sprintf("[ %s ] invalid schedule: %v", key, err))
+			return
+		}
+		if old, ok := s.accounts[key]; ok {
+			if old.schedule == schedule && maps.Equal(old.account, account) {
+				return
+			}
+			s.remove(key)
+		}
+		s.add(key, schedule, account, []job{
+			{schedule.Domain, func() { loadingAccountDomains(cloudProvider, account) }},
+			{schedule.Record, func() { loadingAccountRecords(cloudProvider, account) }},
+			{schedule.Cert, func() { loadingAccountCert(cloudProvider, account["name"]) }},
+		})
+	})
+
+	// 自定义记录的证书采集使用全局证书采集周期
+	current[public.CustomRecords] = true
+	if schedule, err := config.ScheduleOf(nil); err == nil {
+		if old, ok := s.accounts[public.CustomRecords]; !ok || old.schedule != schedule {
+			s.remove(public.CustomRecords)
+			s.add(public.CustomRecords, schedule, nil, []job{
+				{schedule.Cert, loadingCustomRecordCert},
+			})
+		}
+	}
+
+	for key := range s.accounts {
+		if !current[key] {
+			s.remove(key)
+		}
+	}
+}
+
+// add 注册账号的定时任务，调用方需持有 s.mu
+func (s *scheduler) add(key string, schedule public.AccountSchedule, account map[string]string, tasks []job) {
+	scheduled := &scheduledAccount{account: account, schedule: schedule}
+	for _, j := range tasks {
+		id, err := s.cron.AddFunc(j.spec, withJitter(schedule.Jitter, j.fn))
+		if err != nil {
+			logger.Error(fmt.Sprintf("[ %s ] add cron job %q failed: %v", key, j.spec, err))
+			continue
+		}
+		scheduled.entries = append(scheduled.entries, id)
+	}
+	s.accounts[key] = scheduled
+}
+
+// remove 移除账号的定时任务，调用方需持有 s.mu
+func (s *scheduler) remove(key string) {
+	scheduled, ok := s.accounts[key]
+	if !ok {
+		return
+	}
+	for _, id := range scheduled.entries {
+		s.cron.Remove(id)
+	}
+	delete(s.accounts, key)
+}
+
+// withJitter 在任务执行前随机延迟 [0, jitter)，使各账号错开请求服务商接口的时间
+func withJitter(jitter time.Duration, fn func()) func() {
+	return func() {
+		if jitter > 0 {
+			time.Sleep(rand.N(jitter))
+		}
+		fn()
+	}
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
@@ -108,7 +109,7 @@ func NewAliyunPVTZClientWithSTS(accessKeyId, accessKeySecret, securityToken, reg
 }
 
 // assumeRole 使用 STS 获取临时凭证
-func (a *AliyunDNS) assumeRole() (*STSCredentials, error) {
+func (a *AliyunDNS) assumeRole(ctx context.Context) (*STSCredentials, error) {
 	if a.account.RoleArn == "" {
 		return nil, fmt.Errorf("roleArn 未配置")
 	}
@@ -135,7 +136,9 @@ func (a *AliyunDNS) assumeRole() (*STSCredentials, error) {
 		DurationSeconds: tea.Int64(3600), // 1小时
 	}
 
-	response, err := stsClient.AssumeRole(request)
+	response, err := callWithContext(ctx, func() (*sts.AssumeRoleResponse, error) {
+		return stsClient.AssumeRole(request)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("AssumeRole 失败: %v", err)
 	}
@@ -159,7 +162,7 @@ func (a *AliyunDNS) assumeRole() (*STSCredentials, error) {
 }
 
 // getValidCredentials 获取有效的凭证（自动刷新）
-func (a *AliyunDNS) getValidCredentials() (*STSCredentials, error) {
+func (a *AliyunDNS) getValidCredentials(ctx context.Context) (*STSCredentials, error) {
 	// 如果没有配置 STS，返回 nil
 	if a.account.RoleArn == "" {
 		return nil, nil
@@ -182,7 +185,7 @@ func (a *AliyunDNS) getValidCredentials() (*STSCredentials, error) {
 	}
 
 	// 获取新凭证
-	newCreds, err := a.assumeRole()
+	newCreds, err := a.assumeRole(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -194,8 +197,8 @@ func (a *AliyunDNS) getValidCredentials() (*STSCredentials, error) {
 }
 
 // createDNSClient 创建DNS客户端（支持STS）
-func (a *AliyunDNS) createDNSClient() (*alidns.Client, error) {
-	creds, err := a.getValidCredentials()
+func (a *AliyunDNS) createDNSClient(ctx context.Context) (*alidns.Client, error) {
+	creds, err := a.getValidCredentials(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("获取STS凭证失败: %v", err)
 	}
@@ -210,8 +213,8 @@ func (a *AliyunDNS) createDNSClient() (*alidns.Client, error) {
 }
 
 // createPVTZClient 创建私网DNS客户端（支持STS）
-func (a *AliyunDNS) createPVTZClient(region string) (*pvtz.Client, error) {
-	creds, err := a.getValidCredentials()
+func (a *AliyunDNS) createPVTZClient(ctx context.Context, region string) (*pvtz.Client, error) {
+	creds, err := a.getValidCredentials(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("获取STS凭证失败: %v", err)
 	}
@@ -232,7 +235,7 @@ func NewAliyunDNS(account public.Account) (*AliyunDNS, error) {
 	}
 
 	// 初始化客户端
-	client, err := aliyunDNS.createDNSClient()
+	client, err := aliyunDNS.createDNSClient(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -242,11 +245,11 @@ func NewAliyunDNS(account public.Account) (*AliyunDNS, error) {
 }
 
 // ListDomains 获取域名列表（公网+内网）
-func (a *AliyunDNS) ListDomains() ([]Domain, error) {
+func (a *AliyunDNS) ListDomains(ctx context.Context) ([]Domain, error) {
 	var allDomains []Domain
 
 	// 1. 采集公网域名
-	publicDomains, err := a.listPublicDomains()
+	publicDomains, err := a.listPublicDomains(ctx)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Cloudname: %s,采集公网域名失败: %v", a.account.CloudName, err))
 		// 不中断流程，继续采集内网域名
@@ -258,7 +261,7 @@ func (a *AliyunDNS) ListDomains() ([]Domain, error) {
 	// 2. 根据配置决定是否采集内网域名
 	var privateDomains []Domain
 	if a.account.EnablePrivateDNS {
-		privateDomains, err = a.listPrivateDomains()
+		privateDomains, err = a.listPrivateDomains(ctx)
 		if err != nil {
 			logger.Error(fmt.Sprintf("Cloudname: %s,采集内网域名失败: %v", a.account.CloudName, err))
 			// 不中断流程
@@ -270,6 +273,11 @@ func (a *AliyunDNS) ListDomains() ([]Domain, error) {
 		logger.Info(fmt.Sprintf("Cloudname: %s,内网域名监控已禁用，跳过内网域名采集", a.account.CloudName))
 	}
 
+	// 超时或取消时整体失败，避免返回不完整的数据
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	logger.Info(fmt.Sprintf("总共采集到 %d 个域名（公网: %d, 内网: %d）",
 		len(allDomains), len(publicDomains), len(privateDomains)))
 
@@ -277,8 +285,8 @@ func (a *AliyunDNS) ListDomains() ([]Domain, error) {
 }
 
 // listPublicDomains 采集公网域名
-func (a *AliyunDNS) listPublicDomains() ([]Domain, error) {
-	client, err := a.createDNSClient()
+func (a *AliyunDNS) listPublicDomains(ctx context.Context) ([]Domain, error) {
+	client, err := a.createDNSClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +301,9 @@ func (a *AliyunDNS) listPublicDomains() ([]Domain, error) {
 			PageSize:   tea.Int64(pageSize),
 		}
 
-		response, err := client.DescribeDomains(request)
+		response, err := callWithContext(ctx, func() (*alidns.DescribeDomainsResponse, error) {
+			return client.DescribeDomains(request)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("Cloudname: %s, 查询公网DNS域名失败: %v", a.account.CloudName, err)
 		}
@@ -320,9 +330,9 @@ func (a *AliyunDNS) listPublicDomains() ([]Domain, error) {
 }
 
 // listPrivateDomains 采集内网域名
-func (a *AliyunDNS) listPrivateDomains() ([]Domain, error) {
+func (a *AliyunDNS) listPrivateDomains(ctx context.Context) ([]Domain, error) {
 	// 私网DNS需要指定地域，这里使用杭州作为默认地域
-	client, err := a.createPVTZClient("cn-hangzhou")
+	client, err := a.createPVTZClient(ctx, "cn-hangzhou")
 	if err != nil {
 		return nil, err
 	}
@@ -337,7 +347,9 @@ func (a *AliyunDNS) listPrivateDomains() ([]Domain, error) {
 			PageSize:   tea.Int32(pageSize),
 		}
 
-		response, err := client.DescribeZones(request)
+		response, err := callWithContext(ctx, func() (*pvtz.DescribeZonesResponse, error) {
+			return client.DescribeZones(request)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("Cloudname: %s,查询内网DNS域名失败: %v", a.account.CloudName, err)
 		}
@@ -427,11 +439,23 @@ func (a *AliyunDNS) convertPrivateDomainToCommon(zone *pvtz.DescribeZonesRespons
 }
 
 // ListRecords 获取记录列表（公网+内网）
-func (a *AliyunDNS) ListRecords() ([]Record, error) {
-	var allRecords []Record
+func (a *AliyunDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var (
+		allRecords     []Record
+		publicDomains  []Domain
+		privateDomains []Domain
+	)
+	// 按域名类型拆分，内网域名需使用 PrivateZone 接口查询
+	for _, domain := range domains {
+		if domain.DomainType == "private" {
+			privateDomains = append(privateDomains, domain)
+		} else {
+			publicDomains = append(publicDomains, domain)
+		}
+	}
 
 	// 1. 采集公网DNS记录
-	publicRecords, err := a.listPublicRecords()
+	publicRecords, err := a.listPublicRecords(ctx, publicDomains)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Cloudname: %s,采集公网DNS记录失败: %v", a.account.CloudName, err))
 		// 不中断流程，继续采集内网记录
@@ -443,7 +467,7 @@ func (a *AliyunDNS) ListRecords() ([]Record, error) {
 	// 2. 根据配置决定是否采集内网DNS记录
 	var privateRecords []Record
 	if a.account.EnablePrivateDNS {
-		privateRecords, err = a.listPrivateRecords()
+		privateRecords, err = a.listPrivateRecords(ctx, privateDomains)
 		if err != nil {
 			logger.Error(fmt.Sprintf("Cloudname: %s, 采集内网DNS记录失败: %v", a.account.CloudName, err))
 			// 不中断流程
@@ -455,6 +479,11 @@ func (a *AliyunDNS) ListRecords() ([]Record, error) {
 		logger.Info(fmt.Sprintf("Cloudname: %s,内网域名监控已禁用，跳过内网DNS记录采集", a.account.CloudName))
 	}
 
+	// 超时或取消时整体失败，避免返回不完整的数据
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	logger.Info(fmt.Sprintf("总共采集到 %d 条DNS记录（公网: %d, 内网: %d）",
 		len(allRecords), len(publicRecords), len(privateRecords)))
 
@@ -462,14 +491,8 @@ func (a *AliyunDNS) ListRecords() ([]Record, error) {
 }
 
 // listPublicRecords 采集公网DNS记录
-func (a *AliyunDNS) listPublicRecords() ([]Record, error) {
-	// 首先获取所有公网域名
-	publicDomains, err := a.listPublicDomains()
-	if err != nil {
-		return nil, fmt.Errorf("Cloudname: %s,获取公网域名列表失败: %v", a.account.CloudName, err)
-	}
-
-	client, err := a.createDNSClient()
+func (a *AliyunDNS) listPublicRecords(ctx context.Context, publicDomains []Domain) ([]Record, error) {
+	client, err := a.createDNSClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -478,7 +501,10 @@ func (a *AliyunDNS) listPublicRecords() ([]Record, error) {
 
 	// 为每个公网域名获取DNS记录
 	for _, domain := range publicDomains {
-		records, err := a.getDomainRecords(client, domain.DomainName, domain.DomainID, "public")
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		records, err := a.getDomainRecords(ctx, client, domain.DomainName, domain.DomainID, "public")
 		if err != nil {
 			logger.Error(fmt.Sprintf("Cloudname: %s, 获取域名 %s 的DNS记录失败: %v", a.account.CloudName, domain.DomainName, err))
 			continue // 继续处理下一个域名
@@ -490,14 +516,8 @@ func (a *AliyunDNS) listPublicRecords() ([]Record, error) {
 }
 
 // listPrivateRecords 采集内网DNS记录
-func (a *AliyunDNS) listPrivateRecords() ([]Record, error) {
-	// 首先获取所有内网域名
-	privateDomains, err := a.listPrivateDomains()
-	if err != nil {
-		return nil, fmt.Errorf("Cloudname: %s,获取内网域名列表失败: %v", a.account.CloudName, err)
-	}
-
-	client, err := a.createPVTZClient("cn-hangzhou")
+func (a *AliyunDNS) listPrivateRecords(ctx context.Context, privateDomains []Domain) ([]Record, error) {
+	client, err := a.createPVTZClient(ctx, "cn-hangzhou")
 	if err != nil {
 		return nil, err
 	}
@@ -506,9 +526,12 @@ func (a *AliyunDNS) listPrivateRecords() ([]Record, error) {
 
 	// 为每个内网域名获取DNS记录
 	for _, domain := range privateDomains {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		// 从 domain.DomainID 中提取 zoneId (格式: private_<zoneId>)
 		zoneId := strings.TrimPrefix(domain.DomainID, "private_")
-		records, err := a.getPrivateZoneRecords(client, zoneId, domain.DomainName, domain.DomainID, "private")
+		records, err := a.getPrivateZoneRecords(ctx, client, zoneId, domain.DomainName, domain.DomainID, "private")
 		if err != nil {
 			logger.Error(fmt.Sprintf("Cloudname: %s,获取内网域名 %s 的DNS记录失败: %v", a.account.CloudName, domain.DomainName, err))
 			continue // 继续处理下一个域名
@@ -520,7 +543,7 @@ func (a *AliyunDNS) listPrivateRecords() ([]Record, error) {
 }
 
 // getDomainRecords 获取公网域名的DNS记录
-func (a *AliyunDNS) getDomainRecords(client *alidns.Client, domainName, domainID, domainType string) ([]Record, error) {
+func (a *AliyunDNS) getDomainRecords(ctx context.Context, client *alidns.Client, domainName, domainID, domainType string) ([]Record, error) {
 	var allRecords []Record
 	pageNumber := int64(1)
 	pageSize := int64(500)
@@ -532,7 +555,9 @@ func (a *AliyunDNS) getDomainRecords(client *alidns.Client, domainName, domainID
 			PageSize:   tea.Int64(pageSize),
 		}
 
-		response, err := client.DescribeDomainRecords(request)
+		response, err := callWithContext(ctx, func() (*alidns.DescribeDomainRecordsResponse, error) {
+			return client.DescribeDomainRecords(request)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("Cloudname: %s,查询域名 %s 的DNS记录失败: %v", a.account.CloudName, domainName, err)
 		}
@@ -558,7 +583,7 @@ func (a *AliyunDNS) getDomainRecords(client *alidns.Client, domainName, domainID
 }
 
 // getPrivateZoneRecords 获取内网域名的DNS记录
-func (a *AliyunDNS) getPrivateZoneRecords(client *pvtz.Client, zoneId, domainName, domainID, domainType string) ([]Record, error) {
+func (a *AliyunDNS) getPrivateZoneRecords(ctx context.Context, client *pvtz.Client, zoneId, domainName, domainID, domainType string) ([]Record, error) {
 	var allRecords []Record
 	pageNumber := int32(1)
 	pageSize := int32(100)
@@ -570,7 +595,9 @@ func (a *AliyunDNS) getPrivateZoneRecords(client *pvtz.Client, zoneId, domainNam
 			PageSize:   tea.Int32(pageSize),
 		}
 
-		response, err := client.DescribeZoneRecords(request)
+		response, err := callWithContext(ctx, func() (*pvtz.DescribeZoneRecordsResponse, error) {
+			return client.DescribeZoneRecords(request)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("Cloudname: %s, 查询内网域名 %s 的DNS记录失败: %v", a.account.CloudName, domainName, err)
 		}
@@ -1,87 +1,178 @@
 package provider
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/alibabacloud-go/tea/tea"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/aws/aws-sdk-go-v2/service/route53domains"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
 	"github.com/golang-module/carbon/v2"
-	"golang.org/x/net/context"
 )
 
 type AmazonDNS struct {
-	account public.Account
-	client  *route53.Client
+	account              public.Account
+	sessionToken         string // 临时凭证的会话令牌，与 secretId/secretKey 一同使用
+	profile              string // 共享配置文件（~/.aws/config、~/.aws/credentials）中的 profile
+	externalID           string // AssumeRole 时使用的外部ID
+	webIdentityTokenFile string // Web Identity 令牌文件，配置后使用 roleArn 以 AssumeRoleWithWebIdentity 方式认证
 }
 
+// awsCachedConfig 缓存的 aws 配置，key 为加载时认证相关配置的摘要
+type awsCachedConfig struct {
+	key string
+	cfg aws.Config
+}
+
+// 每次采集都会通过 Factory 新建实例，aws 配置需在包级别按账号名称缓存，其中的凭证缓存才能跨采集复用
+var (
+	awsConfigs      = make(map[string]awsCachedConfig)
+	awsConfigsMutex sync.Mutex
+)
+
 const region = "us-east-1"
 
-func NewAwsDnsClient(secretID string, secretKey string) *route53.Client {
-	return route53.New(route53.Options{
-		Credentials:      aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(secretID, secretKey, "")),
-		Region:           region,
-		RetryMaxAttempts: 3,
+// awsRoleSessionName AssumeRole 的会话名称
+const awsRoleSessionName = "dns_exporter_session"
+
+func NewAwsDnsClient(cfg aws.Config) *route53.Client {
+	return route53.NewFromConfig(cfg, func(o *route53.Options) {
+		o.RetryMaxAttempts = 3
 	})
 }
 
-func NewAwsDns(account public.Account) *AmazonDNS {
-	client := NewAwsDnsClient(account.SecretID, account.SecretKey)
-	return &AmazonDNS{
-		account: account,
-		client:  client,
+func NewAwsDomainClient(cfg aws.Config) *route53domains.Client {
+	return route53domains.NewFromConfig(cfg)
+}
+
+// loadConfig 加载 aws 配置，凭证按以下顺序确定：
+//  1. 配置了 webIdentityTokenFile 时，使用令牌以 AssumeRoleWithWebIdentity 扮演 roleArn
+//  2. 配置了 secretId/secretKey 时使用静态凭证，否则使用默认凭证链（环境变量、共享配置文件、IRSA、ECS 及 EC2 实例角色）
+//  3. 在 2 的基础上配置了 roleArn 时，以其为源凭证 AssumeRole，可用于跨账号访问
+//
+// 凭证由 aws.CredentialsCache 缓存并在过期前自动刷新，因此同一账号的配置只需加载一次
+func (a *AmazonDNS) loadConfig(ctx context.Context) (aws.Config, error) {
+	// 认证相关的配置任一变化（如热加载修改了密钥）都需要重新加载
+	key := credentialKey(a.account.SecretID, a.account.SecretKey, a.sessionToken, a.profile, a.account.RoleArn, a.externalID, a.webIdentityTokenFile)
+	awsConfigsMutex.Lock()
+	defer awsConfigsMutex.Unlock()
+	if cached, ok := awsConfigs[a.account.CloudName]; ok && cached.key == key {
+		return cached.cfg, nil
+	}
+	opts := []func(*config.LoadOptions) error{
+		config.WithRegion(region),
+	}
+	if a.profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(a.profile))
 	}
+	if a.account.SecretID != "" && a.account.SecretKey != "" {
+		opts = append(opts, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(a.account.SecretID, a.account.SecretKey, a.sessionToken)))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("load aws config failed: %v", err)
+	}
+	if a.account.RoleArn != "" {
+		stsClient := sts.NewFromConfig(cfg)
+		if a.webIdentityTokenFile != "" {
+			cfg.Credentials = aws.NewCredentialsCache(stscreds.NewWebIdentityRoleProvider(stsClient, a.account.RoleArn, stscreds.IdentityTokenFile(a.webIdentityTokenFile), func(o *stscreds.WebIdentityRoleOptions) {
+				o.RoleSessionName = awsRoleSessionName
+			}))
+		} else {
+			cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsClient, a.account.RoleArn, func(o *stscreds.AssumeRoleOptions) {
+				o.RoleSessionName = awsRoleSessionName
+				if a.externalID != "" {
+					o.ExternalID = aws.String(a.externalID)
+				}
+			}))
+		}
+	} else if a.webIdentityTokenFile != "" {
+		return aws.Config{}, fmt.Errorf("roleArn is required when webIdentityTokenFile is set")
+	}
+	awsConfigs[a.account.CloudName] = awsCachedConfig{key: key, cfg: cfg}
+	return cfg, nil
 }
 
-func NewAwsDomainClient(secretID string, secretKey string) *route53domains.Client {
-	return route53domains.New(route53domains.Options{
-		Credentials: aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(secretID, secretKey, "")),
-		Region:      region,
-	})
+// releaseAwsConfig 清理账号缓存的 aws 配置
+func releaseAwsConfig(cloudName string) {
+	awsConfigsMutex.Lock()
+	defer awsConfigsMutex.Unlock()
+	delete(awsConfigs, cloudName)
 }
 
-func (a *AmazonDNS) ListDomains() ([]Domain, error) {
-	ad := NewAwsDns(public.Account{
-		CloudProvider: a.account.CloudProvider,
-		CloudName:     a.account.CloudName,
-		SecretID:      a.account.SecretID,
-		SecretKey:     a.account.SecretKey,
-	})
-	a.client = ad.client
+func (a *AmazonDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	cfg, err := a.loadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := NewAwsDnsClient(cfg)
 	var (
 		dataObj []Domain
 		wg      sync.WaitGroup
 		mu      sync.Mutex
 	)
-	domains, err := a.getDomainList()
+	domainClient := NewAwsDomainClient(cfg)
+	domains, err := a.getDomainList(ctx, client)
 	if err != nil {
 		return nil, err
 	}
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 	for _, domain := range domains {
+		private := domain.Config != nil && domain.Config.PrivateZone
+		// 私有托管区域仅在开启内网域名监控时采集
+		if private && !a.account.EnablePrivateDNS {
+			continue
+		}
 		wg.Add(1)
 		go func(domain types.HostedZone) {
 			defer wg.Done()
-			<-ticker.C
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			domainID := strings.TrimPrefix(tea.StringValue(domain.Id), "/hostedzone/")
 			domainName := strings.TrimSuffix(tea.StringValue(domain.Name), ".")
-			domainCreateAndExpiryDate := a.getDomainCreateAndExpiryDate(domainName)
+			var remark string
+			if domain.Config != nil {
+				remark = tea.StringValue(domain.Config.Comment)
+			}
+			var (
+				domainCreateAndExpiryDate Domain
+				domainType                = "public"
+				vpcs                      string
+			)
+			if private {
+				// 私有托管区域不存在注册信息，查询其关联的 VPC
+				domainType = "private"
+				var err error
+				if vpcs, err = a.getHostedZoneVPCs(ctx, client, domainID); err != nil {
+					logger.Error(fmt.Sprintf("[ %s_%s ] get vpc associations of %s failed: %v", a.account.CloudProvider, a.account.CloudName, domainName, err))
+				}
+			} else {
+				domainCreateAndExpiryDate = a.getDomainCreateAndExpiryDate(ctx, domainClient, domainName)
+			}
 			mu.Lock()
 			dataObj = append(dataObj, Domain{
 				CloudProvider:   a.account.CloudProvider,
 				CloudName:       a.account.CloudName,
-				DomainID:        strings.TrimPrefix(tea.StringValue(domain.Id), "/hostedzone/"),
+				DomainID:        domainID,
 				DomainName:      domainName,
-				DomainRemark:    tea.StringValue(nil),
+				DomainType:      domainType,
+				DomainRemark:    remark,
 				DomainStatus:    "enable",
+				DomainVPCs:      vpcs,
 				CreatedDate:     domainCreateAndExpiryDate.CreatedDate,
 				ExpiryDate:      domainCreateAndExpiryDate.ExpiryDate,
 				DaysUntilExpiry: domainCreateAndExpiryDate.DaysUntilExpiry,
@@ -90,96 +181,179 @@ func (a *AmazonDNS) ListDomains() ([]Domain, error) {
 		}(domain)
 	}
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	return dataObj, nil
 }
 
-func (a *AmazonDNS) ListRecords() ([]Record, error) {
+func (a *AmazonDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
 	var (
 		dataObj []Record
-		domains []Domain
 		wg      sync.WaitGroup
 		mu      sync.Mutex
 	)
-	ad := NewAwsDns(public.Account{
-		CloudProvider: a.account.CloudProvider,
-		CloudName:     a.account.CloudName,
-		SecretID:      a.account.SecretID,
-		SecretKey:     a.account.SecretKey,
-	})
-	a.client = ad.client
-	rst, err := public.Cache.Get(public.DomainList + "_" + a.account.CloudProvider + "_" + a.account.CloudName)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(rst, &domains)
+	cfg, err := a.loadConfig(ctx)
 	if err != nil {
 		return nil, err
 	}
+	client := NewAwsDnsClient(cfg)
+	// 以托管区域ID为键，公网与私有托管区域可能同名
 	results := make(map[string][]types.ResourceRecordSet)
+	domainByID := make(map[string]Domain)
+	for _, domain := range domains {
+		domainByID[domain.DomainID] = domain
+	}
 	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+loop:
 	for _, domain := range domains {
-		wg.Add(1)
 		// aws 接口并发限制
-		time.Sleep(200 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-time.After(200 * time.Millisecond):
+		}
+		wg.Add(1)
 		go func(domain Domain) {
 			defer wg.Done()
-			<-ticker.C
-			records, err := a.getRecordList(domain.DomainID)
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			records, err := a.getRecordList(ctx, client, domain.DomainID)
 			if err != nil {
-				fmt.Printf("get record list failed: %s\n", err)
+				logger.Error(fmt.Sprintf("[ %s_%s ] get record list of %s failed: %v", a.account.CloudProvider, a.account.CloudName, domain.DomainName, err))
+				return
 			}
 			mu.Lock()
-			results[domain.DomainName] = records
+			results[domain.DomainID] = records
 			mu.Unlock()
 		}(domain)
 	}
 	wg.Wait()
-	for domain, record := range results {
-		for _, record := range record {
-			recordInfo := Record{
-				CloudProvider: a.account.CloudProvider,
-				CloudName:     a.account.CloudName,
-				DomainName:    domain,
-				RecordID:      public.GetID(),
-				RecordType:    string(record.Type),
-				RecordWeight:  fmt.Sprintf("%d", record.Weight),
-				RecordStatus:  oneStatus("enable"),
-				RecordRemark:  tea.StringValue(nil),
-				UpdateTime:    carbon.CreateFromTimestampMilli(tea.Int64Value(nil)).ToDateTimeString(),
-				FullRecord:    strings.TrimSuffix(tea.StringValue(record.Name), "."),
-			}
-			// aws域名返回完整域名处理
-			recordName := strings.TrimSuffix(tea.StringValue(record.Name), ".")
-			if len(strings.Split(recordName, ".")) > 2 {
-				recordInfo.RecordName = strings.TrimSuffix(strings.Replace(recordName, domain, "", 1), ".")
-			} else {
-				recordInfo.RecordName = "@"
-			}
-			if record.TTL == nil {
-				recordInfo.RecordTTL = "300"
-			} else {
-				recordInfo.RecordTTL = fmt.Sprintf("%d", *record.TTL)
-			}
-			if record.ResourceRecords != nil {
-				for _, record := range record.ResourceRecords {
-					recordInfo.RecordValue = tea.StringValue(record.Value)
-					dataObj = append(dataObj, recordInfo)
-				}
-			} else {
-				fmt.Println("record value is nil", record)
-			}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for domainID, records := range results {
+		for _, record := range records {
+			dataObj = append(dataObj, a.convertRecordSet(domainByID[domainID], record)...)
 		}
 	}
 	return dataObj, nil
 }
 
+// convertRecordSet 将记录集拆分为单条记录，别名记录以别名目标作为记录值
+// 路由策略相关的字段（标识符、策略、区域、地理位置、健康检查）作用于记录集中的每条记录
+func (a *AmazonDNS) convertRecordSet(domain Domain, record types.ResourceRecordSet) []Record {
+	domainID := domain.DomainID
+	recordInfo := Record{
+		CloudProvider: a.account.CloudProvider,
+		CloudName:     a.account.CloudName,
+		DomainName:    domain.DomainName,
+		DomainType:    domain.DomainType,
+		RecordType:    string(record.Type),
+		RecordStatus:  oneStatus("enable"),
+		RecordRemark:  tea.StringValue(nil),
+		RecordAlias:   strconv.FormatBool(record.AliasTarget != nil),
+		SetIdentifier: tea.StringValue(record.SetIdentifier),
+		RoutingPolicy: awsRoutingPolicy(record),
+		RecordRegion:  string(record.Region),
+		GeoLocation:   awsGeoLocation(record),
+		HealthCheckID: tea.StringValue(record.HealthCheckId),
+		UpdateTime:    carbon.CreateFromTimestampMilli(tea.Int64Value(nil)).ToDateTimeString(),
+		FullRecord:    strings.TrimSuffix(tea.StringValue(record.Name), "."),
+	}
+	// aws域名返回完整域名处理
+	recordName := strings.TrimSuffix(tea.StringValue(record.Name), ".")
+	if len(strings.Split(recordName, ".")) > 2 {
+		recordInfo.RecordName = strings.TrimSuffix(strings.Replace(recordName, domain.DomainName, "", 1), ".")
+	} else {
+		recordInfo.RecordName = "@"
+	}
+	if record.Weight != nil {
+		recordInfo.RecordWeight = fmt.Sprintf("%d", *record.Weight)
+	}
+	var values []string
+	if record.AliasTarget != nil {
+		// 别名记录没有TTL，解析时使用别名目标的TTL
+		values = append(values, strings.TrimSuffix(tea.StringValue(record.AliasTarget.DNSName), "."))
+	} else {
+		if record.TTL == nil {
+			recordInfo.RecordTTL = "300"
+		} else {
+			recordInfo.RecordTTL = fmt.Sprintf("%d", *record.TTL)
+		}
+		for _, value := range record.ResourceRecords {
+			values = append(values, tea.StringValue(value.Value))
+		}
+	}
+	var rst []Record
+	for _, value := range values {
+		recordInfo.RecordValue = value
+		// Route53 记录没有ID，根据托管区域、记录名、类型、值与标识符生成稳定ID
+		recordInfo.RecordID = public.GetRecordID(domainID, recordInfo.FullRecord, recordInfo.RecordType, recordInfo.RecordValue, recordInfo.SetIdentifier)
+		rst = append(rst, recordInfo)
+	}
+	return rst
+}
+
+// awsRoutingPolicy 根据记录集中设置的字段推断路由策略
+func awsRoutingPolicy(record types.ResourceRecordSet) string {
+	switch {
+	case record.Weight != nil:
+		return "weighted"
+	case record.Region != "":
+		return "latency"
+	case record.GeoLocation != nil:
+		return "geolocation"
+	case record.GeoProximityLocation != nil:
+		return "geoproximity"
+	case record.Failover != "":
+		return "failover-" + strings.ToLower(string(record.Failover))
+	case record.MultiValueAnswer != nil && *record.MultiValueAnswer:
+		return "multivalue"
+	case record.CidrRoutingConfig != nil:
+		return "cidr"
+	default:
+		return "simple"
+	}
+}
+
+// awsGeoLocation 格式化地理位置，如 NA、US、US-CA，默认位置为 *
+// 地理邻近路由返回 AWS 区域、本地区域组或经纬度，IP 路由返回 CIDR 集合中的位置名称
+func awsGeoLocation(record types.ResourceRecordSet) string {
+	if geo := record.GeoLocation; geo != nil {
+		if geo.ContinentCode != nil {
+			return tea.StringValue(geo.ContinentCode)
+		}
+		location := tea.StringValue(geo.CountryCode)
+		if geo.SubdivisionCode != nil {
+			location += "-" + tea.StringValue(geo.SubdivisionCode)
+		}
+		return location
+	}
+	if geo := record.GeoProximityLocation; geo != nil {
+		switch {
+		case geo.AWSRegion != nil:
+			return tea.StringValue(geo.AWSRegion)
+		case geo.LocalZoneGroup != nil:
+			return tea.StringValue(geo.LocalZoneGroup)
+		case geo.Coordinates != nil:
+			return tea.StringValue(geo.Coordinates.Latitude) + "," + tea.StringValue(geo.Coordinates.Longitude)
+		}
+	}
+	if record.CidrRoutingConfig != nil {
+		return tea.StringValue(record.CidrRoutingConfig.LocationName)
+	}
+	return ""
+}
+
 // https://docs.aws.amazon.com/Route53/latest/APIReference/API_ListHostedZones.html
 // getDomainList 获取托管区域解析域名列表
-func (a *AmazonDNS) getDomainList() (rst []types.HostedZone, err error) {
-	client := NewAwsDnsClient(a.account.SecretID, a.account.SecretKey)
+func (a *AmazonDNS) getDomainList(ctx context.Context, client *route53.Client) (rst []types.HostedZone, err error) {
 	var Marker *string
 	for {
-		output, err := client.ListHostedZones(context.Background(), &route53.ListHostedZonesInput{
+		output, err := client.ListHostedZones(ctx, &route53.ListHostedZonesInput{
 			Marker: Marker,
 		})
 		if err != nil {
@@ -194,15 +368,30 @@ func (a *AmazonDNS) getDomainList() (rst []types.HostedZone, err error) {
 	return
 }
 
+// https://docs.aws.amazon.com/Route53/latest/APIReference/API_GetHostedZone.html
+// getHostedZoneVPCs 获取私有托管区域关联的 VPC，格式为 vpcId:region，多个用逗号分隔
+func (a *AmazonDNS) getHostedZoneVPCs(ctx context.Context, client *route53.Client, domainId string) (string, error) {
+	output, err := client.GetHostedZone(ctx, &route53.GetHostedZoneInput{
+		Id: tea.String(domainId),
+	})
+	if err != nil {
+		return "", err
+	}
+	var vpcs []string
+	for _, v := range output.VPCs {
+		vpcs = append(vpcs, fmt.Sprintf("%s:%s", tea.StringValue(v.VPCId), v.VPCRegion))
+	}
+	return strings.Join(vpcs, ","), nil
+}
+
 // https://docs.aws.amazon.com/Route53/latest/APIReference/API_ListResourceRecordSets.html
 // getRecordList 获取解析记录
-func (a *AmazonDNS) getRecordList(domainId string) (rst []types.ResourceRecordSet, err error) {
-	client := NewAwsDnsClient(a.account.SecretID, a.account.SecretKey)
+func (a *AmazonDNS) getRecordList(ctx context.Context, client *route53.Client, domainId string) (rst []types.ResourceRecordSet, err error) {
 	var startRecordIdentifier *string
 	var startRecordType types.RRType
 	var startRecordName *string
 	for {
-		output, err := client.ListResourceRecordSets(context.Background(), &route53.ListResourceRecordSetsInput{
+		output, err := client.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
 			HostedZoneId:          tea.String(domainId),
 			StartRecordIdentifier: startRecordIdentifier,
 			StartRecordType:       startRecordType,
@@ -225,9 +414,8 @@ func (a *AmazonDNS) getRecordList(domainId string) (rst []types.ResourceRecordSe
 
 // 域名详情接口 https://docs.aws.amazon.com/Route53/latest/APIReference/API_domains_GetDomainDetail.html
 // getDomainCreateAndExpiryDate 获取域名创建时间、过期时间, 通过域名详情获取
-func (a *AmazonDNS) getDomainCreateAndExpiryDate(domainName string) (d Domain) {
-	client := NewAwsDomainClient(a.account.SecretID, a.account.SecretKey)
-	domainDetail, err := client.GetDomainDetail(context.Background(), &route53domains.GetDomainDetailInput{
+func (a *AmazonDNS) getDomainCreateAndExpiryDate(ctx context.Context, client *route53domains.Client, domainName string) (d Domain) {
+	domainDetail, err := client.GetDomainDetail(ctx, &route53domains.GetDomainDetailInput{
 		DomainName: tea.String(domainName),
 	})
 	if err != nil {
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	"github.com/miekg/dns"
+)
+
+// AXFRDNS 通过区域传送（AXFR）从自建权威服务器（如 BIND、PowerDNS）采集解析记录
+type AXFRDNS struct {
+	account       public.Account
+	server        string   // 主服务器地址，格式为 host[:port]，默认端口 53
+	zones         []string // 需要传送的区域
+	tsigName      string   // TSIG 密钥名称，为空时不签名
+	tsigSecret    string   // TSIG 密钥，base64 编码
+	tsigAlgorithm string   // TSIG 算法，默认 hmac-sha256
+}
+
+// ListDomains 获取域名列表，即配置中的区域
+func (a *AXFRDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	if a.server == "" {
+		return nil, errors.New("missing axfr server")
+	}
+	if len(a.zones) == 0 {
+		return nil, errors.New("no axfr zone configured")
+	}
+	var dataObj []Domain
+	for _, zone := range a.zones {
+		name := strings.TrimSuffix(zone, ".")
+		dataObj = append(dataObj, Domain{
+			CloudProvider: a.account.CloudProvider,
+			CloudName:     a.account.CloudName,
+			DomainID:      name,
+			DomainName:    name,
+			DomainType:    "public",
+			DomainStatus:  "enable",
+		})
+	}
+	return dataObj, nil
+}
+
+// ListRecords 获取记录列表
+func (a *AXFRDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var (
+		dataObj []Record
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+	)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain Domain) {
+			defer wg.Done()
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			rrs, err := a.transfer(ctx, domain.DomainName)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[ %s_%s ] transfer zone %s failed: %v", a.account.CloudProvider, a.account.CloudName, domain.DomainName, err))
+				return
+			}
+			records := convertRRs(a.account, domain, rrs)
+			mu.Lock()
+			dataObj = append(dataObj, records...)
+			mu.Unlock()
+		}(domain)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// transfer 对区域发起 AXFR 请求，返回区域中的全部记录
+// 连接的建立与读写均受 ctx 约束，超时或取消后关闭连接，避免主服务器无响应时连接与 goroutine 泄漏
+func (a *AXFRDNS) transfer(ctx context.Context, zone string) ([]dns.RR, error) {
+	server := a.server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+	msg := new(dns.Msg)
+	msg.SetAxfr(dns.Fqdn(zone))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", server)
+	if err != nil {
+		return nil, err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	tr := &dns.Transfer{Conn: &dns.Conn{Conn: conn}}
+	defer tr.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := time.Until(deadline)
+		tr.DialTimeout, tr.ReadTimeout, tr.WriteTimeout = timeout, timeout, timeout
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+	if a.tsigName != "" {
+		algorithm := a.tsigAlgorithm
+		if algorithm == "" {
+			algorithm = dns.HmacSHA256
+		}
+		tsigName := dns.Fqdn(a.tsigName)
+		tr.TsigSecret = map[string]string{tsigName: a.tsigSecret}
+		msg.SetTsig(tsigName, dns.Fqdn(algorithm), 300, time.Now().Unix())
+	}
+	envelopes, err := tr.In(msg, server)
+	if err != nil {
+		return nil, err
+	}
+	var rrs []dns.RR
+	for e := range envelopes {
+		if e.Error != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, e.Error
+		}
+		rrs = append(rrs, e.RR...)
+	}
+	// 传送以 SOA 记录开始并以同一条 SOA 记录结束，去掉结尾重复的 SOA
+	if n := len(rrs); n > 1 && rrs[n-1].Header().Rrtype == dns.TypeSOA {
+		rrs = rrs[:n-1]
+	}
+	return rrs, nil
+}
+
+// convertRRs 将 DNS 资源记录转换为通用记录结构，用于不提供原生记录ID的区域传送与区域文件
+func convertRRs(account public.Account, domain Domain, rrs []dns.RR) []Record {
+	var records []Record
+	for _, rr := range rrs {
+		hdr := rr.Header()
+		fullRecord := strings.TrimSuffix(hdr.Name, ".")
+		recordName := strings.TrimSuffix(strings.TrimSuffix(fullRecord, domain.DomainName), ".")
+		if recordName == "" {
+			recordName = "@"
+		}
+		recordType := dns.TypeToString[hdr.Rrtype]
+		value := strings.TrimPrefix(rr.String(), hdr.String())
+		records = append(records, Record{
+			CloudProvider: account.CloudProvider,
+			CloudName:     account.CloudName,
+			DomainName:    domain.DomainName,
+			DomainType:    domain.DomainType,
+			RecordID:      public.GetRecordID(domain.DomainID, fullRecord, recordType, value),
+			RecordType:    recordType,
+			RecordName:    recordName,
+			RecordValue:   value,
+			RecordTTL:     strconv.FormatUint(uint64(hdr.Ttl), 10),
+			RecordStatus:  "enable",
+			FullRecord:    fullRecord,
+		})
+	}
+	return records
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	"github.com/miekg/dns"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogger("error", "text")
+	os.Exit(m.Run())
+}
+
+const (
+	testTSIGName   = "transfer-key."
+	testTSIGSecret = "c2VjcmV0LWtleS1mb3ItdGVzdHMtb25seQ=="
+)
+
+// testZone 测试区域，第一条为 SOA，传送时会在结尾再次发送
+var testZone = []string{
+	"example.com. 3600 IN SOA ns1.example.com. admin.example.com. 1 7200 3600 1209600 300",
+	"example.com. 3600 IN NS ns1.example.com.",
+	"example.com. 600 IN MX 10 mail.example.com.",
+	"www.example.com. 300 IN A 192.0.2.1",
+	"api.example.com. 300 IN CNAME www.example.com.",
+}
+
+// startAXFRServer 启动进程内的权威服务器，tsigSecret 不为空时要求请求使用 TSIG 签名
+func startAXFRServer(t *testing.T, tsigSecret map[string]string) string {
+	t.Helper()
+	var rrs []dns.RR
+	for _, s := range testZone {
+		rr, err := dns.NewRR(s)
+		if err != nil {
+			t.Fatalf("parse rr %q: %v", s, err)
+		}
+		rrs = append(rrs, rr)
+	}
+	handler := dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		if len(tsigSecret) > 0 && (r.IsTsig() == nil || w.TsigStatus() != nil) {
+			m := new(dns.Msg)
+			m.SetRcode(r, dns.RcodeNotAuth)
+			_ = w.WriteMsg(m)
+			return
+		}
+		ch := make(chan *dns.Envelope)
+		tr := new(dns.Transfer)
+		go func() {
+			ch <- &dns.Envelope{RR: rrs}
+			ch <- &dns.Envelope{RR: []dns.RR{rrs[0]}}
+			close(ch)
+		}()
+		_ = tr.Out(w, r, ch)
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	started := make(chan struct{})
+	srv := &dns.Server{
+		Listener:          listener,
+		Handler:           handler,
+		TsigSecret:        tsigSecret,
+		NotifyStartedFunc: func() { close(started) },
+	}
+	go func() { _ = srv.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = srv.Shutdown() })
+	return listener.Addr().String()
+}
+
+func TestAXFRListRecords(t *testing.T) {
+	tests := []struct {
+		name       string
+		serverTSIG map[string]string
+		tsigName   string
+		tsigSecret string
+		wantErr    bool
+	}{
+		{
+			name: "without tsig",
+		},
+		{
+			name:       "with tsig",
+			serverTSIG: map[string]string{testTSIGName: testTSIGSecret},
+			tsigName:   "transfer-key",
+			tsigSecret: testTSIGSecret,
+		},
+		{
+			name:       "wrong tsig key",
+			serverTSIG: map[string]string{testTSIGName: testTSIGSecret},
+			tsigName:   "transfer-key",
+			tsigSecret: "d3Jvbmcta2V5",
+			wantErr:    true,
+		},
+		{
+			name:       "missing tsig",
+			serverTSIG: map[string]string{testTSIGName: testTSIGSecret},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AXFRDNS{
+				account:    public.Account{CloudProvider: public.AXFRDnsProvider, CloudName: "bind1"},
+				server:     startAXFRServer(t, tt.serverTSIG),
+				zones:      []string{"example.com"},
+				tsigName:   tt.tsigName,
+				tsigSecret: tt.tsigSecret,
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if _, err := a.transfer(ctx, "example.com"); (err != nil) != tt.wantErr {
+				t.Fatalf("transfer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			domains, err := a.ListDomains(ctx)
+			if err != nil {
+				t.Fatalf("ListDomains() error = %v", err)
+			}
+			records, err := a.ListRecords(ctx, domains)
+			if err != nil {
+				t.Fatalf("ListRecords() error = %v", err)
+			}
+			if tt.wantErr {
+				// 传送失败的区域只记录日志，不产生任何记录
+				if len(records) != 0 {
+					t.Fatalf("ListRecords() = %d records, want none", len(records))
+				}
+				return
+			}
+			checkExampleRecords(t, records)
+		})
+	}
+}
+
+// checkExampleRecords 校验 testZone 转换后的记录，结尾重复的 SOA 不应出现
+func checkExampleRecords(t *testing.T, records []Record) {
+	t.Helper()
+	want := []Record{
+		{RecordName: "@", RecordType: "MX", RecordValue: "10 mail.example.com.", RecordTTL: "600", FullRecord: "example.com"},
+		{RecordName: "@", RecordType: "NS", RecordValue: "ns1.example.com.", RecordTTL: "3600", FullRecord: "example.com"},
+		{RecordName: "@", RecordType: "SOA", RecordValue: "ns1.example.com. admin.example.com. 1 7200 3600 1209600 300", RecordTTL: "3600", FullRecord: "example.com"},
+		{RecordName: "api", RecordType: "CNAME", RecordValue: "www.example.com.", RecordTTL: "300", FullRecord: "api.example.com"},
+		{RecordName: "www", RecordType: "A", RecordValue: "192.0.2.1", RecordTTL: "300", FullRecord: "www.example.com"},
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].RecordName != records[j].RecordName {
+			return records[i].RecordName < records[j].RecordName
+		}
+		return records[i].RecordType < records[j].RecordType
+	})
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d: %+v", len(records), len(want), records)
+	}
+	for i, w := range want {
+		got := records[i]
+		if got.RecordName != w.RecordName || got.RecordType != w.RecordType || got.RecordValue != w.RecordValue ||
+			got.RecordTTL != w.RecordTTL || got.FullRecord != w.FullRecord {
+			t.Errorf("record %d = %+v, want %+v", i, got, w)
+		}
+		if got.DomainName != "example.com" || got.RecordStatus != "enable" || got.RecordID == "" {
+			t.Errorf("record %d has unexpected domain/status/id: %+v", i, got)
+		}
+	}
+}
+
+// TestAXFRTransferTimeout 主服务器接受连接后不响应时，传送应在 ctx 到期后及时返回
+func TestAXFRTransferTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+	a := &AXFRDNS{
+		account: public.Account{CloudProvider: public.AXFRDnsProvider, CloudName: "bind1"},
+		server:  listener.Addr().String(),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := a.transfer(ctx, "example.com"); err == nil {
+		t.Fatal("transfer() error = nil, want timeout")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("transfer() returned after %s, want about 200ms", elapsed)
+	}
+}
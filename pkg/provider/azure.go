@@ -0,0 +1,362 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+)
+
+// AzureDNS Azure DNS 及 Azure Private DNS
+// 公网域名与内网域名分属两个资源提供程序，需分别在每个订阅下查询
+type AzureDNS struct {
+	account       public.Account
+	tenantID      string   // 服务主体所属的租户ID
+	subscriptions []string // 需要采集的订阅ID
+}
+
+// azureRecordSet 公网与内网记录集的公共字段，记录值已按类型转换为字符串
+type azureRecordSet struct {
+	ID     string
+	Name   string
+	Type   string
+	Fqdn   string
+	TTL    int64
+	Values []string
+}
+
+// newCredential 使用服务主体的客户端密码初始化凭证
+func (a *AzureDNS) newCredential() (azcore.TokenCredential, error) {
+	if a.tenantID == "" || a.account.SecretID == "" || a.account.SecretKey == "" {
+		return nil, errors.New("missing azure tenantId, clientId or clientSecret")
+	}
+	if len(a.subscriptions) == 0 {
+		return nil, errors.New("no azure subscription configured")
+	}
+	return azidentity.NewClientSecretCredential(a.tenantID, a.account.SecretID, a.account.SecretKey, nil)
+}
+
+// ListDomains 获取域名列表（公网+内网）
+func (a *AzureDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	cred, err := a.newCredential()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		dataObj  []Domain
+		lastErr  error
+		failed   int
+		attempts int
+	)
+	for _, subscription := range a.subscriptions {
+		attempts++
+		publicDomains, err := a.listPublicDomains(ctx, cred, subscription)
+		if err != nil {
+			failed++
+			lastErr = err
+			logger.Error(fmt.Sprintf("[ %s_%s ] list public zones of subscription %s failed: %v", a.account.CloudProvider, a.account.CloudName, subscription, err))
+		}
+		dataObj = append(dataObj, publicDomains...)
+
+		// Private DNS 区域仅在开启内网域名监控时采集
+		if !a.account.EnablePrivateDNS {
+			continue
+		}
+		attempts++
+		privateDomains, err := a.listPrivateDomains(ctx, cred, subscription)
+		if err != nil {
+			failed++
+			lastErr = err
+			logger.Error(fmt.Sprintf("[ %s_%s ] list private zones of subscription %s failed: %v", a.account.CloudProvider, a.account.CloudName, subscription, err))
+		}
+		dataObj = append(dataObj, privateDomains...)
+	}
+	// 所有订阅的公网与内网域名均查询失败时整体失败
+	if failed == attempts {
+		return nil, lastErr
+	}
+	// 超时或取消时整体失败，避免返回不完整的数据
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// listPublicDomains 分页查询订阅下的公网域名
+func (a *AzureDNS) listPublicDomains(ctx context.Context, cred azcore.TokenCredential, subscription string) ([]Domain, error) {
+	client, err := armdns.NewZonesClient(subscription, cred, nil)
+	if err != nil {
+		return nil, err
+	}
+	var dataObj []Domain
+	pager := client.NewListPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.Value {
+			dataObj = append(dataObj, Domain{
+				CloudProvider: a.account.CloudProvider,
+				CloudName:     a.account.CloudName,
+				DomainID:      deref(v.ID),
+				DomainName:    deref(v.Name),
+				DomainType:    "public",
+				DomainStatus:  "enable",
+			})
+		}
+	}
+	return dataObj, nil
+}
+
+// listPrivateDomains 分页查询订阅下的内网域名
+func (a *AzureDNS) listPrivateDomains(ctx context.Context, cred azcore.TokenCredential, subscription string) ([]Domain, error) {
+	client, err := armprivatedns.NewPrivateZonesClient(subscription, cred, nil)
+	if err != nil {
+		return nil, err
+	}
+	var dataObj []Domain
+	pager := client.NewListPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.Value {
+			dataObj = append(dataObj, Domain{
+				CloudProvider: a.account.CloudProvider,
+				CloudName:     a.account.CloudName,
+				DomainID:      deref(v.ID),
+				DomainName:    deref(v.Name),
+				DomainType:    "private",
+				DomainStatus:  "enable",
+			})
+		}
+	}
+	return dataObj, nil
+}
+
+// ListRecords 获取记录列表
+func (a *AzureDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var (
+		dataObj []Record
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+	)
+	cred, err := a.newCredential()
+	if err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain Domain) {
+			defer wg.Done()
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			recordSets, err := a.getRecordSetList(ctx, cred, domain)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[ %s_%s ] get record list of %s failed: %v", a.account.CloudProvider, a.account.CloudName, domain.DomainName, err))
+				return
+			}
+			var records []Record
+			for _, v := range recordSets {
+				records = append(records, a.convertRecordSet(domain, v)...)
+			}
+			mu.Lock()
+			dataObj = append(dataObj, records...)
+			mu.Unlock()
+		}(domain)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// getRecordSetList 分页查询域名下的记录集，订阅与资源组从域名的资源ID中解析
+func (a *AzureDNS) getRecordSetList(ctx context.Context, cred azcore.TokenCredential, domain Domain) ([]azureRecordSet, error) {
+	id, err := arm.ParseResourceID(domain.DomainID)
+	if err != nil {
+		return nil, fmt.Errorf("parse zone id %s failed: %v", domain.DomainID, err)
+	}
+	var recordSets []azureRecordSet
+	if domain.DomainType == "private" {
+		client, err := armprivatedns.NewRecordSetsClient(id.SubscriptionID, cred, nil)
+		if err != nil {
+			return nil, err
+		}
+		pager := client.NewListPager(id.ResourceGroupName, id.Name, nil)
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range page.Value {
+				recordSets = append(recordSets, newAzurePrivateRecordSet(v))
+			}
+		}
+		return recordSets, nil
+	}
+	client, err := armdns.NewRecordSetsClient(id.SubscriptionID, cred, nil)
+	if err != nil {
+		return nil, err
+	}
+	pager := client.NewListAllByDNSZonePager(id.ResourceGroupName, id.Name, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.Value {
+			recordSets = append(recordSets, newAzurePublicRecordSet(v))
+		}
+	}
+	return recordSets, nil
+}
+
+// convertRecordSet 将记录集拆分为单条记录，记录集中的每个记录值对应一条记录
+func (a *AzureDNS) convertRecordSet(domain Domain, v azureRecordSet) []Record {
+	fullRecord := strings.TrimSuffix(v.Fqdn, ".")
+	if fullRecord == "" {
+		fullRecord = domain.DomainName
+		if v.Name != "@" {
+			fullRecord = v.Name + "." + domain.DomainName
+		}
+	}
+	var records []Record
+	for _, value := range v.Values {
+		records = append(records, Record{
+			CloudProvider: a.account.CloudProvider,
+			CloudName:     a.account.CloudName,
+			DomainName:    domain.DomainName,
+			DomainType:    domain.DomainType,
+			RecordID:      public.GetRecordID(v.ID, value),
+			RecordType:    v.Type,
+			RecordName:    v.Name,
+			RecordValue:   value,
+			RecordTTL:     strconv.FormatInt(v.TTL, 10),
+			RecordStatus:  "enable",
+			FullRecord:    fullRecord,
+		})
+	}
+	return records
+}
+
+// newAzurePublicRecordSet 转换公网记录集，别名记录的值为目标资源ID
+func newAzurePublicRecordSet(v *armdns.RecordSet) azureRecordSet {
+	rs := azureRecordSet{ID: deref(v.ID), Name: deref(v.Name), Type: azureRecordType(deref(v.Type))}
+	p := v.Properties
+	if p == nil {
+		return rs
+	}
+	rs.Fqdn, rs.TTL = deref(p.Fqdn), deref(p.TTL)
+	for _, r := range p.ARecords {
+		rs.Values = append(rs.Values, deref(r.IPv4Address))
+	}
+	for _, r := range p.AaaaRecords {
+		rs.Values = append(rs.Values, deref(r.IPv6Address))
+	}
+	for _, r := range p.CaaRecords {
+		rs.Values = append(rs.Values, fmt.Sprintf("%d %s %q", deref(r.Flags), deref(r.Tag), deref(r.Value)))
+	}
+	if p.CnameRecord != nil {
+		rs.Values = append(rs.Values, deref(p.CnameRecord.Cname))
+	}
+	for _, r := range p.MxRecords {
+		rs.Values = append(rs.Values, fmt.Sprintf("%d %s", deref(r.Preference), deref(r.Exchange)))
+	}
+	for _, r := range p.NsRecords {
+		rs.Values = append(rs.Values, deref(r.Nsdname))
+	}
+	for _, r := range p.PtrRecords {
+		rs.Values = append(rs.Values, deref(r.Ptrdname))
+	}
+	if r := p.SoaRecord; r != nil {
+		rs.Values = append(rs.Values, fmt.Sprintf("%s %s %d %d %d %d %d", deref(r.Host), deref(r.Email),
+			deref(r.SerialNumber), deref(r.RefreshTime), deref(r.RetryTime), deref(r.ExpireTime), deref(r.MinimumTTL)))
+	}
+	for _, r := range p.SrvRecords {
+		rs.Values = append(rs.Values, fmt.Sprintf("%d %d %d %s", deref(r.Priority), deref(r.Weight), deref(r.Port), deref(r.Target)))
+	}
+	for _, r := range p.TxtRecords {
+		rs.Values = append(rs.Values, joinTxt(r.Value))
+	}
+	if len(rs.Values) == 0 && p.TargetResource != nil {
+		rs.Values = append(rs.Values, deref(p.TargetResource.ID))
+	}
+	return rs
+}
+
+// newAzurePrivateRecordSet 转换内网记录集
+func newAzurePrivateRecordSet(v *armprivatedns.RecordSet) azureRecordSet {
+	rs := azureRecordSet{ID: deref(v.ID), Name: deref(v.Name), Type: azureRecordType(deref(v.Type))}
+	p := v.Properties
+	if p == nil {
+		return rs
+	}
+	rs.Fqdn, rs.TTL = deref(p.Fqdn), deref(p.TTL)
+	for _, r := range p.ARecords {
+		rs.Values = append(rs.Values, deref(r.IPv4Address))
+	}
+	for _, r := range p.AaaaRecords {
+		rs.Values = append(rs.Values, deref(r.IPv6Address))
+	}
+	if p.CnameRecord != nil {
+		rs.Values = append(rs.Values, deref(p.CnameRecord.Cname))
+	}
+	for _, r := range p.MxRecords {
+		rs.Values = append(rs.Values, fmt.Sprintf("%d %s", deref(r.Preference), deref(r.Exchange)))
+	}
+	for _, r := range p.PtrRecords {
+		rs.Values = append(rs.Values, deref(r.Ptrdname))
+	}
+	if r := p.SoaRecord; r != nil {
+		rs.Values = append(rs.Values, fmt.Sprintf("%s %s %d %d %d %d %d", deref(r.Host), deref(r.Email),
+			deref(r.SerialNumber), deref(r.RefreshTime), deref(r.RetryTime), deref(r.ExpireTime), deref(r.MinimumTTL)))
+	}
+	for _, r := range p.SrvRecords {
+		rs.Values = append(rs.Values, fmt.Sprintf("%d %d %d %s", deref(r.Priority), deref(r.Weight), deref(r.Port), deref(r.Target)))
+	}
+	for _, r := range p.TxtRecords {
+		rs.Values = append(rs.Values, joinTxt(r.Value))
+	}
+	return rs
+}
+
+// azureRecordType 从资源类型中提取记录类型，如 Microsoft.Network/dnszones/A -> A
+func azureRecordType(resourceType string) string {
+	return resourceType[strings.LastIndex(resourceType, "/")+1:]
+}
+
+// joinTxt 拼接 TXT 记录中的多个字符串
+func joinTxt(values []*string) string {
+	var sb strings.Builder
+	for _, v := range values {
+		sb.WriteString(deref(v))
+	}
+	return sb.String()
+}
+
+// deref 获取指针的值，指针为空时返回零值
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
@@ -2,54 +2,63 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/alibabacloud-go/tea/tea"
 	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/golang-module/carbon/v2"
 )
 
 type CloudFlareDNS struct {
-	account public.Account
-	client  *cloudflare.API
+	account    public.Account
+	apiToken   string   // API 令牌，配置后优先于全局 API Key + 邮箱认证
+	accountIDs []string // 需要采集的账户ID，为空时采集令牌可访问的全部域名
 }
 
+// cloudflareRegistrarLogged 已输出过 Registrar 查询失败日志的账号，每个账号只记录一次，账号变更后重新记录
+var cloudflareRegistrarLogged sync.Map
+
+// cloudflareUnknownExpiryDays 到期时间未知时 domain_list 的取值，避免与当天到期的 0 混淆
+const cloudflareUnknownExpiryDays = -1
+
+// cloudflareRecordPageSize 解析记录接口单页最大条数
+const cloudflareRecordPageSize = 5000
+
 type Header struct {
 	XAuthEmail  interface{} `json:"X-Auth-Email"`
 	XAuthKey    interface{} `json:"X-Auth-Key"`
 	ContentType string      `json:"Content-Type"`
 }
 
-func NewCloudflareDNSClient(token string, email string) (*cloudflare.API, error) {
-	return cloudflare.New(token, email)
+// NewCloudflareDNSClient 初始化客户端，apiToken 不为空时使用 API 令牌认证，否则使用全局 API Key + 邮箱认证
+func NewCloudflareDNSClient(apiToken, key, email string) (*cloudflare.API, error) {
+	if apiToken != "" {
+		return cloudflare.NewWithAPIToken(apiToken)
+	}
+	return cloudflare.New(key, email)
 }
 
-func NewCloudFlareDNS(account public.Account) *CloudFlareDNS {
-	client, _ := NewCloudflareDNSClient(account.SecretKey, account.SecretID)
-	return &CloudFlareDNS{
-		account: account,
-		client:  client,
-	}
+// newClient 使用账号配置初始化客户端
+func (cf *CloudFlareDNS) newClient() (*cloudflare.API, error) {
+	return NewCloudflareDNSClient(cf.apiToken, cf.account.SecretKey, cf.account.SecretID)
 }
 
-func (cf *CloudFlareDNS) ListDomains() ([]Domain, error) {
-	cfd := NewCloudFlareDNS(public.Account{
-		CloudProvider: cf.account.CloudProvider,
-		CloudName:     cf.account.CloudName,
-		SecretID:      cf.account.SecretID,
-		SecretKey:     cf.account.SecretKey,
-	})
-	cf.client = cfd.client
+func (cf *CloudFlareDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	client, err := cf.newClient()
+	if err != nil {
+		return nil, err
+	}
 	var (
 		dataObj []Domain
 		wg      sync.WaitGroup
 		mu      sync.Mutex
 	)
-	domains, err := cf.getDomainList()
+	domains, err := cf.getDomainList(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -59,16 +68,19 @@ func (cf *CloudFlareDNS) ListDomains() ([]Domain, error) {
 		wg.Add(1)
 		go func(domain cloudflare.Zone) {
 			defer wg.Done()
-			<-ticker.C
-			domainCreateAndExpiryDate, _ := cf.getDomainCreateAndExpiryDate(domain)
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			domainCreateAndExpiryDate := cf.getDomainCreateAndExpiryDate(ctx, client, domain)
 			mu.Lock()
 			dataObj = append(dataObj, Domain{
-				CloudName:       domain.Name,
+				CloudName:       cf.account.CloudName,
 				CloudProvider:   cf.account.CloudProvider,
 				CreatedDate:     domainCreateAndExpiryDate.CreatedDate,
 				DaysUntilExpiry: domainCreateAndExpiryDate.DaysUntilExpiry,
 				DomainID:        domain.ID,
 				DomainName:      domain.Name,
+				DomainType:      "public",
 				DomainRemark:    tea.StringValue(nil),
 				DomainStatus:    domain.Status,
 				ExpiryDate:      domainCreateAndExpiryDate.ExpiryDate,
@@ -77,134 +89,130 @@ func (cf *CloudFlareDNS) ListDomains() ([]Domain, error) {
 		}(domain)
 	}
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	return dataObj, err
 }
 
-func (cf *CloudFlareDNS) ListRecords() ([]Record, error) {
+func (cf *CloudFlareDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
 	var (
 		dataObj []Record
-		domains []Domain
 		wg      sync.WaitGroup
 		mu      sync.Mutex
 	)
-	cfd := NewCloudFlareDNS(public.Account{
-		CloudProvider: cf.account.CloudProvider,
-		CloudName:     cf.account.CloudName,
-		SecretID:      cf.account.SecretID,
-		SecretKey:     cf.account.SecretKey,
-	})
-	cf.client = cfd.client
-	rst, err := public.Cache.Get(public.DomainList + "_" + cf.account.CloudProvider + "_" + cf.account.CloudName)
+	client, err := cf.newClient()
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal(rst, &domains)
-	if err != nil {
-		return nil, err
-	}
-	results := make(map[string][]cloudflare.DNSRecord)
+	results := make(map[Domain][]cloudflare.DNSRecord)
 	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
 	for _, domain := range domains {
 		wg.Add(1)
 		go func(domain Domain) {
 			defer wg.Done()
-			<-ticker.C
-			records, err := cf.getRecordList(domain.DomainName)
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			records, err := cf.getRecordList(ctx, client, domain.DomainID)
 			if err != nil {
-				fmt.Printf("cloudflare get record list error: %v", err)
+				logger.Error(fmt.Sprintf("[ %s_%s ] get record list of %s failed: %v", cf.account.CloudProvider, cf.account.CloudName, domain.DomainName, err))
 				return
 			}
 			mu.Lock()
-			results[domain.DomainName] = records
+			results[domain] = records
 			mu.Unlock()
 		}(domain)
 	}
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	for domain, records := range results {
 		for _, record := range records {
+			// MX、SRV、URI 等记录的优先级单独返回，拼接到记录值前与区域文件格式保持一致
+			value := record.Content
+			if record.Priority != nil {
+				value = fmt.Sprintf("%d %s", *record.Priority, record.Content)
+			}
 			dataObj = append(dataObj, Record{
 				CloudName:     cf.account.CloudName,
 				CloudProvider: cf.account.CloudProvider,
-				DomainName:    domain,
+				DomainName:    domain.DomainName,
+				DomainType:    domain.DomainType,
 				RecordID:      record.ID,
 				RecordName:    record.Name,
 				RecordType:    record.Type,
-				RecordRemark:  tea.StringValue(nil),
+				RecordValue:   value,
+				RecordRemark:  record.Comment,
 				RecordStatus:  "enable",
 				RecordTTL:     fmt.Sprintf("%d", record.TTL),
+				RecordProxied: strconv.FormatBool(tea.BoolValue(record.Proxied)),
 				FullRecord:    record.Name,
 			})
 		}
 	}
-	return dataObj, err
+	return dataObj, nil
 }
 
-// getDomainList 获取解析域域名列表
-func (cf *CloudFlareDNS) getDomainList() (rst []cloudflare.Zone, err error) {
-	client, err := NewCloudflareDNSClient(cf.account.SecretKey, cf.account.SecretID)
-	if err != nil {
-		fmt.Printf("cloudflare client init error: %v", err)
-		return
-	}
-	zones, err := client.ListZones(context.Background())
-	if err != nil {
-		fmt.Printf("cloudflare list zones error: %v", err)
-		return
-	}
-	rst = append(rst, zones...)
-	return
-}
-
-func (cf *CloudFlareDNS) getAccountId() (account cloudflare.Account, err error) {
-	client, _ := NewCloudflareDNSClient(cf.account.SecretKey, cf.account.SecretID)
-	accounts, _, err := client.Accounts(context.Background(), cloudflare.AccountsListParams{})
-	if err != nil {
-		return
+// getDomainList 获取解析域域名列表，配置了账户ID时仅获取这些账户下的域名
+func (cf *CloudFlareDNS) getDomainList(ctx context.Context, client *cloudflare.API) ([]cloudflare.Zone, error) {
+	if len(cf.accountIDs) == 0 {
+		zones, err := client.ListZonesContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return zones.Result, nil
 	}
-	for _, a := range accounts {
-		account = a
+	var rst []cloudflare.Zone
+	for _, accountID := range cf.accountIDs {
+		zones, err := client.ListZonesContext(ctx, cloudflare.WithZoneFilters("", accountID, ""))
+		if err != nil {
+			return nil, fmt.Errorf("list zones of account %s failed: %v", accountID, err)
+		}
+		rst = append(rst, zones.Result...)
 	}
-	return
+	return rst, nil
 }
 
-func (cf *CloudFlareDNS) getRecordList(domain string) (rst []cloudflare.DNSRecord, err error) {
-	page := 1
-	pageSize := 2
-	client, _ := NewCloudflareDNSClient(cf.account.SecretKey, cf.account.SecretID)
-	zoneID, err := client.ZoneIDByName(domain)
-	if err != nil {
-		return
-	}
-	for {
-		records, r, err := client.ListDNSRecords(context.Background(), cloudflare.ZoneIdentifier(zoneID), cloudflare.ListDNSRecordsParams{
-			ResultInfo: cloudflare.ResultInfo{Page: page, PerPage: pageSize},
+// getRecordList 获取解析记录，zoneID 即 ListDomains 返回的 DomainID
+func (cf *CloudFlareDNS) getRecordList(ctx context.Context, client *cloudflare.API, zoneID string) (rst []cloudflare.DNSRecord, err error) {
+	for page := 1; ; page++ {
+		records, r, err := client.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListDNSRecordsParams{
+			ResultInfo: cloudflare.ResultInfo{Page: page, PerPage: cloudflareRecordPageSize},
 		})
 		if err != nil {
 			return nil, err
 		}
 		rst = append(rst, records...)
-		if page*pageSize > r.Total {
+		if !r.HasMorePages() {
 			break
 		}
-		page++
 	}
 	return
 }
 
-func (cf *CloudFlareDNS) getDomainCreateAndExpiryDate(domain cloudflare.Zone) (d Domain, err error) {
-	client, err := NewCloudflareDNSClient(cf.account.SecretKey, cf.account.SecretID)
-	if err != nil {
+// getDomainCreateAndExpiryDate 获取域名的注册及到期时间
+// 域名未在 Cloudflare 注册或令牌缺少 Registrar 权限时日期为空，距离到期天数为 cloudflareUnknownExpiryDays，不影响域名列表的采集
+func (cf *CloudFlareDNS) getDomainCreateAndExpiryDate(ctx context.Context, client *cloudflare.API, domain cloudflare.Zone) (d Domain) {
+	d.DaysUntilExpiry = cloudflareUnknownExpiryDays
+	if domain.Account.ID == "" {
 		return
 	}
-	account, err := cf.getAccountId()
+	domainInfo, err := client.RegistrarDomain(ctx, domain.Account.ID, domain.Name)
 	if err != nil {
+		if _, logged := cloudflareRegistrarLogged.LoadOrStore(cf.account.CloudName, struct{}{}); !logged {
+			logger.Warning(fmt.Sprintf("[ %s_%s ] get registrar info of %s failed, expiry of unregistered domains is left unknown: %v", cf.account.CloudProvider, cf.account.CloudName, domain.Name, err))
+		}
 		return
 	}
-	domainInfo, err := client.RegistrarDomain(context.Background(), account.ID, domain.Name)
-	d.CreatedDate = domainInfo.CreatedAt.String()
-	d.ExpiryDate = domainInfo.ExpiresAt.String()
-	if d.ExpiryDate != "" {
-		d.DaysUntilExpiry = carbon.Now().DiffInDays(carbon.Parse(d.ExpiryDate))
+	if !domainInfo.CreatedAt.IsZero() {
+		d.CreatedDate = carbon.CreateFromStdTime(domainInfo.CreatedAt).ToDateTimeString()
+	}
+	if !domainInfo.ExpiresAt.IsZero() {
+		d.ExpiryDate = carbon.CreateFromStdTime(domainInfo.ExpiresAt).ToDateTimeString()
+		d.DaysUntilExpiry = carbon.Now().DiffInDays(carbon.CreateFromStdTime(domainInfo.ExpiresAt))
 	}
 	return
 }
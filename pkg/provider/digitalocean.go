@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/alibabacloud-go/tea/tea"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/dnslib/digitalocean"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+)
+
+// DigitalOceanDNS DigitalOcean Domains
+type DigitalOceanDNS struct {
+	account public.Account
+}
+
+// ListDomains 获取域名列表
+func (d *DigitalOceanDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	client, err := digitalocean.NewClient(d.account.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	var dataObj []Domain
+	for page := 1; ; page++ {
+		rst, err := client.Domains.List(ctx, digitalocean.NewPageOption(page, 200))
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range rst.Domains {
+			dataObj = append(dataObj, Domain{
+				CloudProvider: d.account.CloudProvider,
+				CloudName:     d.account.CloudName,
+				DomainID:      v.Name,
+				DomainName:    v.Name,
+				DomainType:    "public",
+				DomainStatus:  "enable",
+			})
+		}
+		if len(rst.Domains) == 0 || rst.Links.Pages.Next == "" {
+			break
+		}
+	}
+	return dataObj, nil
+}
+
+// ListRecords 获取记录列表
+func (d *DigitalOceanDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var (
+		dataObj []Record
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+	)
+	client, err := digitalocean.NewClient(d.account.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain Domain) {
+			defer wg.Done()
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			rds, err := d.getRecordList(ctx, client, domain.DomainName)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[ %s_%s ] get record list of %s failed: %v", d.account.CloudProvider, d.account.CloudName, domain.DomainName, err))
+				return
+			}
+			var records []Record
+			for _, v := range rds {
+				fullRecord := domain.DomainName
+				if v.Name != "@" && v.Name != "" {
+					fullRecord = v.Name + "." + domain.DomainName
+				}
+				// 优先级、权重与端口单独返回，按区域文件格式拼接到记录值前，与 Cloudflare 保持一致
+				value, weight := v.Data, ""
+				switch v.Type {
+				case "MX":
+					value = fmt.Sprintf("%d %s", tea.IntValue(v.Priority), v.Data)
+				case "SRV":
+					value = fmt.Sprintf("%d %d %d %s", tea.IntValue(v.Priority), tea.IntValue(v.Weight), tea.IntValue(v.Port), v.Data)
+					weight = strconv.Itoa(tea.IntValue(v.Weight))
+				}
+				records = append(records, Record{
+					CloudProvider: d.account.CloudProvider,
+					CloudName:     d.account.CloudName,
+					DomainName:    domain.DomainName,
+					DomainType:    domain.DomainType,
+					RecordID:      strconv.Itoa(v.ID),
+					RecordType:    v.Type,
+					RecordName:    v.Name,
+					RecordValue:   value,
+					RecordTTL:     strconv.Itoa(v.TTL),
+					RecordWeight:  weight,
+					RecordStatus:  "enable",
+					FullRecord:    fullRecord,
+				})
+			}
+			mu.Lock()
+			dataObj = append(dataObj, records...)
+			mu.Unlock()
+		}(domain)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// getRecordList 分页查询域名下的全部解析记录
+func (d *DigitalOceanDNS) getRecordList(ctx context.Context, client *digitalocean.Client, domain string) ([]digitalocean.Record, error) {
+	var records []digitalocean.Record
+	for page := 1; ; page++ {
+		rst, err := client.Records.List(ctx, digitalocean.NewPageOption(page, 200), domain)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rst.DomainRecords...)
+		if len(rst.DomainRecords) == 0 || rst.Links.Pages.Next == "" {
+			break
+		}
+	}
+	return records, nil
+}
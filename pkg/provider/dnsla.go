@@ -1,7 +1,7 @@
 package provider
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"strconv"
 	"sync"
@@ -40,7 +40,7 @@ func NewDNSLaDNS(account public.Account) (*DNSLaDNS, error) {
 }
 
 // ListDomains 获取域名列表
-func (d *DNSLaDNS) ListDomains() ([]Domain, error) {
+func (d *DNSLaDNS) ListDomains(ctx context.Context) ([]Domain, error) {
 	gd, err := NewDNSLaDNS(public.Account{
 		CloudProvider: d.account.CloudProvider,
 		CloudName:     d.account.CloudName,
@@ -52,7 +52,7 @@ func (d *DNSLaDNS) ListDomains() ([]Domain, error) {
 	}
 	d.client = gd.client
 	var dataObj []Domain
-	domains, err := d.getDomainList()
+	domains, err := d.getDomainList(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -73,10 +73,9 @@ func (d *DNSLaDNS) ListDomains() ([]Domain, error) {
 }
 
 // ListRecords 获取记录列表
-func (d *DNSLaDNS) ListRecords() ([]Record, error) {
+func (d *DNSLaDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
 	var (
 		dataObj []Record
-		domains []Domain
 		wg      sync.WaitGroup
 		mu      sync.Mutex
 	)
@@ -90,14 +89,6 @@ func (d *DNSLaDNS) ListRecords() ([]Record, error) {
 		return nil, err
 	}
 	d.client = tcd.client
-	rst, err := public.Cache.Get(public.DomainList + "_" + d.account.CloudProvider + "_" + d.account.CloudName)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(rst, &domains)
-	if err != nil {
-		return nil, err
-	}
 	results := make(map[string][]dnsla.Record)
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
@@ -105,8 +96,10 @@ func (d *DNSLaDNS) ListRecords() ([]Record, error) {
 		wg.Add(1)
 		go func(domainName, domainId string) {
 			defer wg.Done()
-			<-ticker.C
-			records, err := d.getRecordList(domainId)
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			records, err := d.getRecordList(ctx, domainId)
 			if err != nil {
 				logger.Error(fmt.Sprintf("[ %s_%s ] get record list failed: %v", d.account.CloudProvider, d.account.CloudName, err))
 			}
@@ -119,13 +112,16 @@ func (d *DNSLaDNS) ListRecords() ([]Record, error) {
 		}(domain.DomainName, domain.DomainID)
 	}
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	for domain, records := range results {
 		for _, v := range records {
 			dataObj = append(dataObj, Record{
 				CloudProvider: d.account.CloudProvider,
 				CloudName:     d.account.CloudName,
 				DomainName:    domain,
-				RecordID:      public.GetID(),
+				RecordID:      v.ID,
 				RecordType:    getRecordType(v.Type),
 				RecordName:    v.DisplayHost,
 				RecordValue:   v.Data,
@@ -142,8 +138,8 @@ func (d *DNSLaDNS) ListRecords() ([]Record, error) {
 
 // https://www.dns.la/docs/ApiDoc
 // GetDomainList 获取云解析中域名列表
-func (d *DNSLaDNS) getDomainList() ([]dnsla.Domain, error) {
-	domains, err := d.client.Domains.List(dnsla.NewPageOption(1, 500))
+func (d *DNSLaDNS) getDomainList(ctx context.Context) ([]dnsla.Domain, error) {
+	domains, err := d.client.Domains.List(ctx, dnsla.NewPageOption(1, 500))
 	if err != nil {
 		return nil, err
 	}
@@ -153,13 +149,13 @@ func (d *DNSLaDNS) getDomainList() ([]dnsla.Domain, error) {
 
 // https://www.dns.la/docs/ApiDoc
 // RecordList 域名记录列表
-func (d *DNSLaDNS) getRecordList(domain string) ([]dnsla.Record, error) {
+func (d *DNSLaDNS) getRecordList(ctx context.Context, domain string) ([]dnsla.Record, error) {
 	// TODO 目前写死的获取1000条记录
-	rds, err := d.client.Records.List(dnsla.NewPageOption(1, 1000), domain)
+	rds, err := d.client.Records.List(ctx, dnsla.NewPageOption(1, 1000), domain)
 	if err != nil {
-		fmt.Printf("Error listing records: %v\n", err)
+		return nil, err
 	}
-	return rds.Data.Results, err
+	return rds.Data.Results, nil
 }
 
 // RecordType 表示DNS记录类型
@@ -1,7 +1,7 @@
 package provider
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"strconv"
 	"sync"
@@ -10,18 +10,18 @@ import (
 	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
 	"github.com/golang-module/carbon/v2"
 
-	"github.com/alyx/go-daddy/daddy"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/dnslib/godaddy"
 	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
 )
 
 type GodaddyDNS struct {
 	account public.Account
-	client  *daddy.Client
+	client  *godaddy.Client
 }
 
 // NewGodaddyClient 初始化客户端
-func NewGodaddyClient(secretID, secretKey string) (*daddy.Client, error) {
-	client, err := daddy.NewClient(secretID, secretKey, false)
+func NewGodaddyClient(secretID, secretKey string) (*godaddy.Client, error) {
+	client, err := godaddy.NewClient(secretID, secretKey)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +41,7 @@ func NewGodaddyDNS(account public.Account) (*GodaddyDNS, error) {
 }
 
 // ListDomains 获取域名列表
-func (g *GodaddyDNS) ListDomains() ([]Domain, error) {
+func (g *GodaddyDNS) ListDomains(ctx context.Context) ([]Domain, error) {
 	gd, err := NewGodaddyDNS(public.Account{
 		CloudProvider: g.account.CloudProvider,
 		CloudName:     g.account.CloudName,
@@ -53,7 +53,7 @@ func (g *GodaddyDNS) ListDomains() ([]Domain, error) {
 	}
 	g.client = gd.client
 	var dataObj []Domain
-	domains, err := g.getDomainList()
+	domains, err := g.getDomainList(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -74,10 +74,9 @@ func (g *GodaddyDNS) ListDomains() ([]Domain, error) {
 }
 
 // ListRecords 获取记录列表
-func (g *GodaddyDNS) ListRecords() ([]Record, error) {
+func (g *GodaddyDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
 	var (
 		dataObj []Record
-		domains []Domain
 		wg      sync.WaitGroup
 		mu      sync.Mutex
 	)
@@ -91,26 +90,18 @@ func (g *GodaddyDNS) ListRecords() ([]Record, error) {
 		return nil, err
 	}
 	g.client = tcd.client
-	rst, err := public.Cache.Get(public.DomainList + "_" + g.account.CloudProvider + "_" + g.account.CloudName)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(rst, &domains)
-	if err != nil {
-		return nil, err
-	}
-	results := make(map[string][]daddy.DNSRecord)
+	results := make(map[string][]godaddy.Record)
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 	for _, domain := range domains {
 		wg.Add(1)
 		go func(domain string) {
 			defer wg.Done()
-			<-ticker.C
-			records, err := g.getRecordList(domain)
-			if err != nil {
-				logger.Error(fmt.Sprintf("[ %s_%s ] get record list failed: %v", g.account.CloudProvider, g.account.CloudName, err))
+			if err := waitTick(ctx, ticker); err != nil {
+				return
 			}
+			// 失败时已在 getRecordList 中记录日志
+			records, _ := g.getRecordList(ctx, domain)
 			if len(records) == 0 {
 				return
 			}
@@ -120,13 +111,16 @@ func (g *GodaddyDNS) ListRecords() ([]Record, error) {
 		}(domain.DomainName)
 	}
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	for domain, records := range results {
 		for _, v := range records {
 			dataObj = append(dataObj, Record{
 				CloudProvider: g.account.CloudProvider,
 				CloudName:     g.account.CloudName,
 				DomainName:    domain,
-				RecordID:      public.GetID(),
+				RecordID:      public.GetRecordID(domain, v.Name, v.Type, v.Data),
 				RecordType:    v.Type,
 				RecordName:    v.Name,
 				RecordValue:   v.Data,
@@ -143,22 +137,17 @@ func (g *GodaddyDNS) ListRecords() ([]Record, error) {
 
 // https://developer.godaddy.com/doc/endpoint/domains
 // GetDomainList 获取云解析中域名列表
-func (g *GodaddyDNS) getDomainList() ([]daddy.DomainSummary, error) {
-	domains, err := g.client.Domains.List(nil, nil, 0, "", nil, "")
-	if err != nil {
-		return nil, err
-	}
-
-	return domains, nil
+func (g *GodaddyDNS) getDomainList(ctx context.Context) ([]godaddy.Domain, error) {
+	return g.client.Domains.List(ctx)
 }
 
 // https://developer.godaddy.com/doc/endpoint/domains
 // RecordList 域名记录列表
-func (g *GodaddyDNS) getRecordList(domain string) ([]daddy.DNSRecord, error) {
+func (g *GodaddyDNS) getRecordList(ctx context.Context, domain string) ([]godaddy.Record, error) {
 	// TODO 目前写死的获取500条记录
-	rds, err := g.client.Domains.GetRecords(domain, "", "", 0, 500)
+	rds, err := g.client.Records.List(ctx, domain, 0, 500)
 	if err != nil {
-		fmt.Printf("Error listing records: %v\n", err)
+		logger.Error(fmt.Sprintf("[ %s_%s ] list records of %s failed: %v", g.account.CloudProvider, g.account.CloudName, domain, err))
 	}
 	return rds, err
 }
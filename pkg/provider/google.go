@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/dnslib/google"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	"github.com/golang-module/carbon/v2"
+)
+
+// GoogleDNS Google Cloud DNS
+type GoogleDNS struct {
+	account         public.Account
+	credentialsFile string   // 服务账号 JSON 密钥文件路径
+	credentialsJSON string   // 服务账号 JSON 密钥内容，优先于 credentialsFile
+	projects        []string // 需要采集的项目，为空时使用密钥所属的项目
+}
+
+// newClient 初始化客户端
+func (g *GoogleDNS) newClient(ctx context.Context) (*google.Client, error) {
+	credentials := []byte(g.credentialsJSON)
+	if len(credentials) == 0 && g.credentialsFile != "" {
+		data, err := os.ReadFile(g.credentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("read credentials file failed: %v", err)
+		}
+		credentials = data
+	}
+	return google.NewClient(ctx, credentials)
+}
+
+// ListDomains 获取域名列表，内网托管区域仅在开启内网域名监控时采集
+func (g *GoogleDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	client, err := g.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projects := g.projects
+	if len(projects) == 0 {
+		if client.ProjectID == "" {
+			return nil, errors.New("no project configured and the service account key has no project_id")
+		}
+		projects = []string{client.ProjectID}
+	}
+	var dataObj []Domain
+	for _, project := range projects {
+		zones, err := g.getManagedZoneList(ctx, client, project)
+		if err != nil {
+			return nil, fmt.Errorf("list managed zones of project %s failed: %v", project, err)
+		}
+		for _, v := range zones {
+			var vpcs []string
+			if v.Visibility == "private" {
+				if !g.account.EnablePrivateDNS {
+					continue
+				}
+				if v.PrivateVisibilityConfig != nil {
+					for _, network := range v.PrivateVisibilityConfig.Networks {
+						vpcs = append(vpcs, googleNetworkVPC(project, network.NetworkURL))
+					}
+				}
+			}
+			dataObj = append(dataObj, Domain{
+				CloudProvider: g.account.CloudProvider,
+				CloudName:     g.account.CloudName,
+				DomainID:      project + "/" + v.Name, // 托管区域名称仅在项目内唯一
+				DomainName:    strings.TrimSuffix(v.DNSName, "."),
+				DomainType:    v.Visibility,
+				DomainRemark:  v.Description,
+				DomainStatus:  "enable",
+				DomainVPCs:    strings.Join(vpcs, ","),
+				CreatedDate:   carbon.Parse(v.CreationTime).ToDateTimeString(),
+			})
+		}
+	}
+	return dataObj, nil
+}
+
+// ListRecords 获取记录列表
+func (g *GoogleDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var (
+		dataObj []Record
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+	)
+	client, err := g.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain Domain) {
+			defer wg.Done()
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			project, zone, _ := strings.Cut(domain.DomainID, "/")
+			rrsets, err := g.getRecordSetList(ctx, client, project, zone)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[ %s_%s ] get record list of %s failed: %v", g.account.CloudProvider, g.account.CloudName, domain.DomainName, err))
+				return
+			}
+			var records []Record
+			for _, v := range rrsets {
+				fullRecord := strings.TrimSuffix(v.Name, ".")
+				recordName := strings.TrimSuffix(strings.TrimSuffix(fullRecord, domain.DomainName), ".")
+				if recordName == "" {
+					recordName = "@"
+				}
+				for _, value := range v.RRDatas {
+					records = append(records, Record{
+						CloudProvider: g.account.CloudProvider,
+						CloudName:     g.account.CloudName,
+						DomainName:    domain.DomainName,
+						DomainType:    domain.DomainType,
+						RecordID:      public.GetRecordID(domain.DomainID, fullRecord, v.Type, value),
+						RecordType:    v.Type,
+						RecordName:    recordName,
+						RecordValue:   value,
+						RecordTTL:     strconv.Itoa(v.TTL),
+						RecordStatus:  "enable",
+						FullRecord:    fullRecord,
+					})
+				}
+			}
+			mu.Lock()
+			dataObj = append(dataObj, records...)
+			mu.Unlock()
+		}(domain)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// getManagedZoneList 分页查询项目下的托管区域
+func (g *GoogleDNS) getManagedZoneList(ctx context.Context, client *google.Client, project string) ([]google.ManagedZone, error) {
+	var (
+		zones     []google.ManagedZone
+		pageToken string
+	)
+	for {
+		rst, err := client.ManagedZones.List(ctx, project, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, rst.ManagedZones...)
+		if rst.NextPageToken == "" {
+			break
+		}
+		pageToken = rst.NextPageToken
+	}
+	return zones, nil
+}
+
+// getRecordSetList 分页查询托管区域下的记录集
+func (g *GoogleDNS) getRecordSetList(ctx context.Context, client *google.Client, project, zone string) ([]google.ResourceRecordSet, error) {
+	var (
+		rrsets    []google.ResourceRecordSet
+		pageToken string
+	)
+	for {
+		rst, err := client.ResourceRecordSets.List(ctx, project, zone, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		rrsets = append(rrsets, rst.RRSets...)
+		if rst.NextPageToken == "" {
+			break
+		}
+		pageToken = rst.NextPageToken
+	}
+	return rrsets, nil
+}
+
+// googleNetworkVPC 将 VPC 网络的 URL（.../projects/{project}/global/networks/{network}）转换为 network:global 的格式，
+// VPC 网络为全局资源，其他项目的网络（如共享 VPC）带上所属项目，格式为 project/network:global
+func googleNetworkVPC(project, networkURL string) string {
+	parts := strings.Split(strings.TrimSuffix(networkURL, "/"), "/")
+	n := len(parts)
+	if n < 5 || parts[n-2] != "networks" || parts[n-3] != "global" || parts[n-5] != "projects" {
+		return networkURL
+	}
+	if parts[n-4] != project {
+		return parts[n-4] + "/" + parts[n-1] + ":global"
+	}
+	return parts[n-1] + ":global"
+}
@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/dnslib/huawei"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	"github.com/golang-module/carbon/v2"
+)
+
+// HuaweiDNS 华为云 DNS
+// 公网域名为全局资源，通过第一个地域的接口查询；内网域名为地域级资源，需在每个地域分别查询
+type HuaweiDNS struct {
+	account public.Account
+	regions []HuaweiRegion
+}
+
+// HuaweiRegion 华为云地域配置
+type HuaweiRegion struct {
+	Region    string // 地域，如 cn-north-4
+	ProjectID string // 地域对应的项目ID，为空时使用默认项目
+	Endpoint  string // 接口地址，为空时根据地域生成
+}
+
+// NewHuaweiRegions 解析账号中的地域配置
+// region/projectId/endpoint 为默认地域，regions 为内网域名所在的其他地域，格式为 "region[:projectId],..."
+func NewHuaweiRegions(account map[string]string) []HuaweiRegion {
+	regions := []HuaweiRegion{{
+		Region:    account["region"],
+		ProjectID: account["projectId"],
+		Endpoint:  account["endpoint"],
+	}}
+	if regions[0].Region == "" {
+		regions[0].Region = huawei.DefaultRegion
+	}
+	seen := map[string]bool{regions[0].Region: true}
+	for _, v := range splitList(account["regions"]) {
+		region, projectID, _ := strings.Cut(v, ":")
+		if seen[region] {
+			continue
+		}
+		seen[region] = true
+		regions = append(regions, HuaweiRegion{Region: region, ProjectID: projectID})
+	}
+	return regions
+}
+
+// newClient 创建指定地域的客户端
+func (h *HuaweiDNS) newClient(region HuaweiRegion) (*huawei.Client, error) {
+	endpoint := region.Endpoint
+	if endpoint == "" {
+		endpoint = huawei.Endpoint(region.Region)
+	}
+	return huawei.NewClient(h.account.SecretID, h.account.SecretKey, endpoint, region.ProjectID)
+}
+
+// region 根据地域名称获取地域配置
+func (h *HuaweiDNS) region(name string) HuaweiRegion {
+	for _, v := range h.regions {
+		if v.Region == name {
+			return v
+		}
+	}
+	return h.regions[0]
+}
+
+// ListDomains 获取域名列表（公网+内网）
+func (h *HuaweiDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	var dataObj []Domain
+	publicDomains, publicErr := h.listDomains(ctx, h.regions[0], huawei.ZoneTypePublic)
+	if publicErr != nil {
+		logger.Error(fmt.Sprintf("[ %s_%s ] list public zones failed: %v", h.account.CloudProvider, h.account.CloudName, publicErr))
+	}
+	dataObj = append(dataObj, publicDomains...)
+
+	if h.account.EnablePrivateDNS {
+		failed := 0
+		for _, region := range h.regions {
+			privateDomains, err := h.listDomains(ctx, region, huawei.ZoneTypePrivate)
+			if err != nil {
+				failed++
+				logger.Error(fmt.Sprintf("[ %s_%s ] list private zones in %s failed: %v", h.account.CloudProvider, h.account.CloudName, region.Region, err))
+				continue
+			}
+			dataObj = append(dataObj, privateDomains...)
+		}
+		// 公网与所有地域的内网域名均查询失败时整体失败
+		if publicErr != nil && failed == len(h.regions) {
+			return nil, publicErr
+		}
+	} else if publicErr != nil {
+		return nil, publicErr
+	}
+
+	// 超时或取消时整体失败，避免返回不完整的数据
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// listDomains 分页查询指定地域、指定类型的域名
+func (h *HuaweiDNS) listDomains(ctx context.Context, region HuaweiRegion, zoneType string) ([]Domain, error) {
+	client, err := h.newClient(region)
+	if err != nil {
+		return nil, err
+	}
+	var dataObj []Domain
+	for offset := 0; ; {
+		rst, err := client.Zones.List(ctx, huawei.NewPageOption(offset, 500), zoneType)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range rst.Zones {
+			domain := Domain{
+				CloudProvider: h.account.CloudProvider,
+				CloudName:     h.account.CloudName,
+				DomainID:      v.ID,
+				DomainName:    strings.TrimSuffix(v.Name, "."),
+				DomainType:    zoneType,
+				DomainRemark:  v.Description,
+				DomainStatus:  oneStatus(v.Status),
+				CreatedDate:   carbon.Parse(v.CreatedAt).ToDateTimeString(),
+			}
+			if zoneType == huawei.ZoneTypePrivate {
+				domain.Region = region.Region
+			}
+			dataObj = append(dataObj, domain)
+		}
+		offset += len(rst.Zones)
+		if len(rst.Zones) == 0 || offset >= rst.Metadata.TotalCount {
+			break
+		}
+	}
+	return dataObj, nil
+}
+
+// ListRecords 获取记录列表
+func (h *HuaweiDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var (
+		dataObj []Record
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+	)
+	clients := make(map[string]*huawei.Client)
+	for _, domain := range domains {
+		if _, ok := clients[domain.Region]; ok {
+			continue
+		}
+		client, err := h.newClient(h.region(domain.Region))
+		if err != nil {
+			return nil, err
+		}
+		clients[domain.Region] = client
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain Domain) {
+			defer wg.Done()
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			recordSets, err := h.getRecordSetList(ctx, clients[domain.Region], domain.DomainType, domain.DomainID)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[ %s_%s ] get record list of %s failed: %v", h.account.CloudProvider, h.account.CloudName, domain.DomainName, err))
+				return
+			}
+			var records []Record
+			for _, v := range recordSets {
+				records = append(records, h.convertRecordSet(domain, v)...)
+			}
+			mu.Lock()
+			dataObj = append(dataObj, records...)
+			mu.Unlock()
+		}(domain)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// getRecordSetList 分页查询域名下的记录集
+func (h *HuaweiDNS) getRecordSetList(ctx context.Context, client *huawei.Client, zoneType, zoneID string) ([]huawei.RecordSet, error) {
+	var recordSets []huawei.RecordSet
+	for offset := 0; ; {
+		rst, err := client.RecordSets.List(ctx, huawei.NewPageOption(offset, 500), zoneType, zoneID)
+		if err != nil {
+			return nil, err
+		}
+		recordSets = append(recordSets, rst.RecordSets...)
+		offset += len(rst.RecordSets)
+		if len(rst.RecordSets) == 0 || offset >= rst.Metadata.TotalCount {
+			break
+		}
+	}
+	return recordSets, nil
+}
+
+// convertRecordSet 将记录集拆分为单条记录，记录集中的每个记录值对应一条记录
+func (h *HuaweiDNS) convertRecordSet(domain Domain, v huawei.RecordSet) []Record {
+	fullRecord := strings.TrimSuffix(v.Name, ".")
+	recordName := strings.TrimSuffix(strings.TrimSuffix(fullRecord, domain.DomainName), ".")
+	if recordName == "" {
+		recordName = "@"
+	}
+	var weight string
+	if v.Weight != nil {
+		weight = strconv.Itoa(*v.Weight)
+	}
+	var records []Record
+	for _, value := range v.Records {
+		records = append(records, Record{
+			CloudProvider: h.account.CloudProvider,
+			CloudName:     h.account.CloudName,
+			DomainName:    domain.DomainName,
+			DomainType:    domain.DomainType,
+			RecordID:      public.GetRecordID(v.ID, value),
+			RecordType:    v.Type,
+			RecordName:    recordName,
+			RecordValue:   value,
+			RecordTTL:     strconv.Itoa(v.TTL),
+			RecordWeight:  weight,
+			RecordLine:    v.Line,
+			RecordStatus:  oneStatus(v.Status),
+			RecordRemark:  v.Description,
+			UpdateTime:    carbon.Parse(v.UpdatedAt).ToDateTimeString(),
+			FullRecord:    fullRecord,
+		})
+	}
+	return records
+}
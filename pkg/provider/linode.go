@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/dnslib/linode"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	"github.com/golang-module/carbon/v2"
+)
+
+// LinodeDNS Linode Domains
+type LinodeDNS struct {
+	account public.Account
+}
+
+// ListDomains 获取域名列表
+func (l *LinodeDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	client, err := linode.NewClient(l.account.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	var dataObj []Domain
+	for page := 1; ; page++ {
+		rst, err := client.Domains.List(ctx, linode.NewPageOption(page, 500))
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range rst.Data {
+			dataObj = append(dataObj, Domain{
+				CloudProvider: l.account.CloudProvider,
+				CloudName:     l.account.CloudName,
+				DomainID:      strconv.Itoa(v.ID),
+				DomainName:    v.Domain,
+				DomainType:    "public",
+				DomainRemark:  v.Description,
+				DomainStatus:  linodeStatus(v.Status),
+			})
+		}
+		if len(rst.Data) == 0 || page >= rst.Pages {
+			break
+		}
+	}
+	return dataObj, nil
+}
+
+// ListRecords 获取记录列表
+func (l *LinodeDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var (
+		dataObj []Record
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+	)
+	client, err := linode.NewClient(l.account.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain Domain) {
+			defer wg.Done()
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			rds, err := l.getRecordList(ctx, client, domain.DomainID)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[ %s_%s ] get record list of %s failed: %v", l.account.CloudProvider, l.account.CloudName, domain.DomainName, err))
+				return
+			}
+			var records []Record
+			for _, v := range rds {
+				recordName, fullRecord := "@", domain.DomainName
+				if v.Name != "" {
+					recordName, fullRecord = v.Name, v.Name+"."+domain.DomainName
+				}
+				// 优先级、权重与端口单独返回，按区域文件格式拼接到记录值前，与 Cloudflare 保持一致
+				value, weight := v.Target, ""
+				switch v.Type {
+				case "MX":
+					value = fmt.Sprintf("%d %s", v.Priority, v.Target)
+				case "SRV":
+					value = fmt.Sprintf("%d %d %d %s", v.Priority, v.Weight, v.Port, v.Target)
+					weight = strconv.Itoa(v.Weight)
+				}
+				records = append(records, Record{
+					CloudProvider: l.account.CloudProvider,
+					CloudName:     l.account.CloudName,
+					DomainName:    domain.DomainName,
+					DomainType:    domain.DomainType,
+					RecordID:      strconv.Itoa(v.ID),
+					RecordType:    v.Type,
+					RecordName:    recordName,
+					RecordValue:   value,
+					RecordTTL:     strconv.Itoa(v.TTLSec),
+					RecordWeight:  weight,
+					RecordStatus:  "enable",
+					UpdateTime:    carbon.Parse(v.Updated).ToDateTimeString(),
+					FullRecord:    fullRecord,
+				})
+			}
+			mu.Lock()
+			dataObj = append(dataObj, records...)
+			mu.Unlock()
+		}(domain)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// getRecordList 分页查询域名下的全部解析记录
+func (l *LinodeDNS) getRecordList(ctx context.Context, client *linode.Client, domainID string) ([]linode.Record, error) {
+	var records []linode.Record
+	for page := 1; ; page++ {
+		rst, err := client.Records.List(ctx, linode.NewPageOption(page, 500), domainID)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rst.Data...)
+		if len(rst.Data) == 0 || page >= rst.Pages {
+			break
+		}
+	}
+	return records, nil
+}
+
+// linodeStatus 统一域名状态，active 为启用，disabled 为禁用，其余状态原样返回
+func linodeStatus(status string) string {
+	switch status {
+	case "active":
+		return "enable"
+	case "disabled":
+		return "disable"
+	}
+	return status
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/dnslib/powerdns"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	"github.com/golang-module/carbon/v2"
+)
+
+// PowerDNS PowerDNS Authoritative 服务器
+type PowerDNS struct {
+	account  public.Account
+	apiURL   string // webserver 地址，如 http://127.0.0.1:8081
+	serverID string // 服务器ID，默认 localhost
+}
+
+// ListDomains 获取域名列表
+func (p *PowerDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	client, err := powerdns.NewClient(p.apiURL, p.account.SecretKey, p.serverID)
+	if err != nil {
+		return nil, err
+	}
+	zones, err := client.Zones.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var dataObj []Domain
+	for _, v := range zones {
+		dataObj = append(dataObj, Domain{
+			CloudProvider: p.account.CloudProvider,
+			CloudName:     p.account.CloudName,
+			DomainID:      v.ID,
+			DomainName:    strings.TrimSuffix(v.Name, "."),
+			DomainType:    "public",
+			DomainRemark:  v.Account,
+			DomainStatus:  "enable",
+		})
+	}
+	return dataObj, nil
+}
+
+// ListRecords 获取记录列表
+func (p *PowerDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var (
+		dataObj []Record
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+	)
+	client, err := powerdns.NewClient(p.apiURL, p.account.SecretKey, p.serverID)
+	if err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain Domain) {
+			defer wg.Done()
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			zone, err := client.Zones.Get(ctx, domain.DomainID)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[ %s_%s ] get record list of %s failed: %v", p.account.CloudProvider, p.account.CloudName, domain.DomainName, err))
+				return
+			}
+			var records []Record
+			for _, v := range zone.RRSets {
+				records = append(records, p.convertRRSet(domain, v)...)
+			}
+			mu.Lock()
+			dataObj = append(dataObj, records...)
+			mu.Unlock()
+		}(domain)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// convertRRSet 将记录集拆分为单条记录，记录集的备注作用于其中的每条记录
+func (p *PowerDNS) convertRRSet(domain Domain, v powerdns.RRSet) []Record {
+	fullRecord := strings.TrimSuffix(v.Name, ".")
+	recordName := strings.TrimSuffix(strings.TrimSuffix(fullRecord, domain.DomainName), ".")
+	if recordName == "" {
+		recordName = "@"
+	}
+	var (
+		remarks    []string
+		updateTime string
+	)
+	for _, c := range v.Comments {
+		remarks = append(remarks, c.Content)
+		if c.ModifiedAt > 0 {
+			updateTime = carbon.CreateFromTimestamp(c.ModifiedAt).ToDateTimeString()
+		}
+	}
+	var records []Record
+	for _, r := range v.Records {
+		status := "enable"
+		if r.Disabled {
+			status = "disable"
+		}
+		records = append(records, Record{
+			CloudProvider: p.account.CloudProvider,
+			CloudName:     p.account.CloudName,
+			DomainName:    domain.DomainName,
+			DomainType:    domain.DomainType,
+			RecordID:      public.GetRecordID(domain.DomainID, fullRecord, v.Type, r.Content),
+			RecordType:    v.Type,
+			RecordName:    recordName,
+			RecordValue:   r.Content,
+			RecordTTL:     strconv.Itoa(v.TTL),
+			RecordStatus:  status,
+			RecordRemark:  strings.Join(remarks, "; "),
+			UpdateTime:    updateTime,
+			FullRecord:    fullRecord,
+		})
+	}
+	return records
+}
@@ -1,8 +1,12 @@
 package provider
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
 )
@@ -15,11 +19,14 @@ func init() {
 	Factory.Register(public.TencentDnsProvider, func(account map[string]string) DNSProvider {
 		return &TencentCloudDNS{
 			account: public.Account{
-				CloudProvider: public.TencentDnsProvider,
-				CloudName:     account["name"],
-				SecretID:      account["secretId"],
-				SecretKey:     account["secretKey"],
+				CloudProvider:    public.TencentDnsProvider,
+				CloudName:        account["name"],
+				SecretID:         account["secretId"],
+				SecretKey:        account["secretKey"],
+				RoleArn:          account["roleArn"],
+				EnablePrivateDNS: strings.ToLower(account["enablePrivateDNS"]) == "true",
 			},
+			externalID: account["externalId"],
 		}
 	})
 	Factory.Register(public.AliyunDnsProvider, func(account map[string]string) DNSProvider {
@@ -53,11 +60,17 @@ func init() {
 	Factory.Register(public.AmazonDnsProvider, func(account map[string]string) DNSProvider {
 		return &AmazonDNS{
 			account: public.Account{
-				CloudProvider: public.AmazonDnsProvider,
-				CloudName:     account["name"],
-				SecretID:      account["secretId"],
-				SecretKey:     account["secretKey"],
+				CloudProvider:    public.AmazonDnsProvider,
+				CloudName:        account["name"],
+				SecretID:         account["secretId"],
+				SecretKey:        account["secretKey"],
+				RoleArn:          account["roleArn"],
+				EnablePrivateDNS: strings.ToLower(account["enablePrivateDNS"]) == "true",
 			},
+			sessionToken:         account["sessionToken"],
+			profile:              account["profile"],
+			externalID:           account["externalId"],
+			webIdentityTokenFile: account["webIdentityTokenFile"],
 		}
 	})
 	Factory.Register(public.DNSLaDnsProvider, func(account map[string]string) DNSProvider {
@@ -78,6 +91,115 @@ func init() {
 				SecretID:      account["secretId"],
 				SecretKey:     account["secretKey"],
 			},
+			apiToken:   account["apiToken"],
+			accountIDs: splitList(account["accountIds"]),
+		}
+	})
+	Factory.Register(public.HuaweiDnsProvider, func(account map[string]string) DNSProvider {
+		return &HuaweiDNS{
+			account: public.Account{
+				CloudProvider:    public.HuaweiDnsProvider,
+				CloudName:        account["name"],
+				SecretID:         account["secretId"],
+				SecretKey:        account["secretKey"],
+				EnablePrivateDNS: strings.ToLower(account["enablePrivateDNS"]) == "true",
+			},
+			regions: NewHuaweiRegions(account),
+		}
+	})
+	Factory.Register(public.GoogleDnsProvider, func(account map[string]string) DNSProvider {
+		return &GoogleDNS{
+			account: public.Account{
+				CloudProvider:    public.GoogleDnsProvider,
+				CloudName:        account["name"],
+				EnablePrivateDNS: strings.ToLower(account["enablePrivateDNS"]) == "true",
+			},
+			credentialsFile: account["credentialsFile"],
+			credentialsJSON: account["credentialsJSON"],
+			projects:        splitList(account["projects"]),
+		}
+	})
+	Factory.Register(public.AzureDnsProvider, func(account map[string]string) DNSProvider {
+		return &AzureDNS{
+			account: public.Account{
+				CloudProvider:    public.AzureDnsProvider,
+				CloudName:        account["name"],
+				SecretID:         account["clientId"],
+				SecretKey:        account["clientSecret"],
+				EnablePrivateDNS: strings.ToLower(account["enablePrivateDNS"]) == "true",
+			},
+			tenantID:      account["tenantId"],
+			subscriptions: splitList(account["subscriptions"]),
+		}
+	})
+	Factory.Register(public.AXFRDnsProvider, func(account map[string]string) DNSProvider {
+		return &AXFRDNS{
+			account: public.Account{
+				CloudProvider: public.AXFRDnsProvider,
+				CloudName:     account["name"],
+			},
+			server:        account["server"],
+			zones:         splitList(account["zones"]),
+			tsigName:      account["tsigName"],
+			tsigSecret:    account["tsigSecret"],
+			tsigAlgorithm: account["tsigAlgorithm"],
+		}
+	})
+	Factory.Register(public.ZoneFileDnsProvider, func(account map[string]string) DNSProvider {
+		return &ZoneFileDNS{
+			account: public.Account{
+				CloudProvider: public.ZoneFileDnsProvider,
+				CloudName:     account["name"],
+			},
+			files: splitList(account["files"]),
+		}
+	})
+	Factory.Register(public.PowerDnsProvider, func(account map[string]string) DNSProvider {
+		return &PowerDNS{
+			account: public.Account{
+				CloudProvider: public.PowerDnsProvider,
+				CloudName:     account["name"],
+				SecretKey:     account["apiKey"],
+			},
+			apiURL:   account["url"],
+			serverID: account["serverId"],
+		}
+	})
+	Factory.Register(public.DigitalOceanDnsProvider, func(account map[string]string) DNSProvider {
+		return &DigitalOceanDNS{
+			account: public.Account{
+				CloudProvider: public.DigitalOceanDnsProvider,
+				CloudName:     account["name"],
+				SecretKey:     account["secretKey"],
+			},
+		}
+	})
+	Factory.Register(public.LinodeDnsProvider, func(account map[string]string) DNSProvider {
+		return &LinodeDNS{
+			account: public.Account{
+				CloudProvider: public.LinodeDnsProvider,
+				CloudName:     account["name"],
+				SecretKey:     account["secretKey"],
+			},
+		}
+	})
+	Factory.Register(public.VultrDnsProvider, func(account map[string]string) DNSProvider {
+		return &VultrDNS{
+			account: public.Account{
+				CloudProvider: public.VultrDnsProvider,
+				CloudName:     account["name"],
+				SecretKey:     account["secretKey"],
+			},
+		}
+	})
+	Factory.Register(public.VolcengineDnsProvider, func(account map[string]string) DNSProvider {
+		return &VolcengineDNS{
+			account: public.Account{
+				CloudProvider: public.VolcengineDnsProvider,
+				CloudName:     account["name"],
+				SecretID:      account["secretId"],
+				SecretKey:     account["secretKey"],
+			},
 		}
 	})
 }
@@ -94,9 +216,13 @@ type Domain struct {
 	CreatedDate     string `json:"created_date"`
 	ExpiryDate      string `json:"expiry_date"`
 	DaysUntilExpiry int64  `json:"days_until_expiry"`
+	Region          string `json:"region,omitempty"` // 内网域名所在地域，仅用于按地域查询解析记录
+	DomainVPCs      string `json:"domain_vpcs"`      // 内网域名关联的 VPC，格式为 vpcId:region，多个用逗号分隔
 }
 
 // Record 域名记录信息
+// RecordProxied、RecordAlias 等布尔类标签的取值约定：服务商不支持该特性时为空字符串，
+// 支持时每条记录均为 "true" 或 "false"（strconv.FormatBool），以区分"不适用"与"未开启"
 type Record struct {
 	CloudProvider string `json:"cloud_provider"`
 	CloudName     string `json:"cloud_name"`
@@ -108,6 +234,14 @@ type Record struct {
 	RecordValue   string `json:"record_value"`
 	RecordTTL     string `json:"record_ttl"`
 	RecordWeight  string `json:"record_weight"`
+	RecordLine    string `json:"record_line"`     // 解析线路，仅部分服务商支持
+	RecordProxied string `json:"record_proxied"`  // 是否经由服务商代理（如 Cloudflare 橙色云朵），取值见 Record 说明
+	RecordAlias   string `json:"record_alias"`    // 是否为别名记录，记录值为别名目标，取值见 Record 说明
+	SetIdentifier string `json:"set_identifier"`  // 同名记录集的标识符，用于区分不同路由策略的记录
+	RoutingPolicy string `json:"routing_policy"`  // 路由策略，如 simple/weighted/latency/geolocation/failover-primary
+	RecordRegion  string `json:"record_region"`   // 延迟路由的区域
+	GeoLocation   string `json:"geo_location"`    // 地理位置路由的位置
+	HealthCheckID string `json:"health_check_id"` // 关联的健康检查ID
 	RecordStatus  string `json:"record_status"`
 	RecordRemark  string `json:"record_remark"`
 	UpdateTime    string `json:"update_time"`
@@ -146,9 +280,12 @@ type RecordCert struct {
 }
 
 // DNSProvider 接口定义
+// 所有方法都需要遵循 ctx 的取消与超时，超时后应尽快返回 ctx.Err()
 type DNSProvider interface {
-	ListDomains() ([]Domain, error)
-	ListRecords() ([]Record, error)
+	// ListDomains 获取账号下的域名列表
+	ListDomains(ctx context.Context) ([]Domain, error)
+	// ListRecords 获取指定域名的解析记录，domains 通常来自同一账号 ListDomains 的返回值
+	ListRecords(ctx context.Context, domains []Domain) ([]Record, error)
 }
 
 // DNSProviderFactory 用于注册和创建 DNSProvider 实例
@@ -173,14 +310,80 @@ func (f *DNSProviderFactory) Create(cloudProvider string, account map[string]str
 	return nil, fmt.Errorf("unsupported cloud provider: %s", cloudProvider)
 }
 
+// ReleaseAccount 清理账号在包级别缓存的凭证，账号从配置中删除或变更时调用
+func ReleaseAccount(cloudProvider, cloudName string) {
+	switch strings.ToLower(cloudProvider) {
+	case public.AmazonDnsProvider:
+		releaseAwsConfig(cloudName)
+	case public.TencentDnsProvider:
+		releaseTencentCredentials(cloudName)
+	case public.CloudFlareDnsProvider:
+		cloudflareRegistrarLogged.Delete(cloudName)
+	case public.VolcengineDnsProvider:
+		volcengineRegistrarLogged.Delete(cloudName)
+	}
+}
+
+// credentialKey 计算认证相关配置的摘要，用于判断缓存的凭证是否仍与配置一致，避免在缓存中保留明文密钥
+func credentialKey(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// splitList 解析账号配置中以逗号分隔的列表，忽略空值
+func splitList(value string) []string {
+	var list []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
 // 统一记录状态的值
 func oneStatus(status string) string {
 	// tencent 的记录状态是 ENABLE 和 DISABLE
-	if status == "ENABLE" || status == "ACTIVE" || status == "1" {
+	if status == "ENABLE" || status == "ACTIVE" || status == "1" || status == "ENABLED" || status == "enabled" {
 		return "enable"
 	}
-	if status == "DISABLE" {
+	if status == "DISABLE" || status == "disabled" {
 		return "disable"
 	}
 	return status
 }
+
+// callWithContext 执行不支持 context 的阻塞调用，ctx 取消或超时时立即返回 ctx.Err()
+// 注意：底层调用会在后台继续运行直至结束，其结果将被丢弃
+func callWithContext[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	type result struct {
+		value T
+		err   error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		value, err := fn()
+		ch <- result{value: value, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case r := <-ch:
+		return r.value, r.err
+	}
+}
+
+// waitTick 等待限流 ticker 的下一个周期，ctx 结束时返回 ctx.Err()
+func waitTick(ctx context.Context, ticker *time.Ticker) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-ticker.C:
+		return nil
+	}
+}
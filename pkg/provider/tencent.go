@@ -1,8 +1,9 @@
 package provider
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,19 +14,39 @@ import (
 	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
 	dnspod "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/dnspod/v20210323"
 	domain "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/domain/v20180808"
+	sts "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/sts/v20180813"
 )
 
 type TencentCloudDNS struct {
-	account public.Account
-	client  *dnspod.Client
+	account    public.Account
+	client     *dnspod.Client
+	externalID string // AssumeRole 时使用的外部ID
 }
 
+// tencentCachedCredentials 缓存的 STS 临时凭证，key 为获取时认证相关配置的摘要
+type tencentCachedCredentials struct {
+	key   string
+	creds *STSCredentials
+}
+
+// 每次采集都会通过 Factory 新建实例，STS 临时凭证需在包级别按账号名称缓存才能跨采集复用
+var (
+	tencentSTSCredentials      = make(map[string]tencentCachedCredentials)
+	tencentSTSCredentialsMutex sync.RWMutex
+)
+
+// tencentSTSRegion STS 为全局服务，固定使用广州地域签名
+const tencentSTSRegion = "ap-guangzhou"
+
+// tencentPrivateDNSRegion 私有域解析接口的地域，私有域为全局资源，任一地域均可查询全部私有域
+const tencentPrivateDNSRegion = "ap-guangzhou"
+
 // NewTencentClient 初始化客户端
-func NewTencentClient(secretID, secretKey string) (*dnspod.Client, error) {
-	credential := common.NewCredential(secretID, secretKey)
+func NewTencentClient(credential common.CredentialIface) (*dnspod.Client, error) {
 	cpf := profile.NewClientProfile()
 	cpf.HttpProfile.Endpoint = "dnspod.tencentcloudapi.com"
 	client, err := dnspod.NewClient(credential, "", cpf)
@@ -35,37 +56,157 @@ func NewTencentClient(secretID, secretKey string) (*dnspod.Client, error) {
 	return client, nil
 }
 
-// NewTencentCloudDNS 创建 TencentCloudDNS 实例
-func NewTencentCloudDNS(account public.Account) (*TencentCloudDNS, error) {
-	client, err := NewTencentClient(account.SecretID, account.SecretKey)
+// NewTencentDomainClient 初始化域名注册服务客户端
+func NewTencentDomainClient(credential common.CredentialIface) (*domain.Client, error) {
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = "domain.tencentcloudapi.com"
+	return domain.NewClient(credential, "", cpf)
+}
+
+// assumeRole 使用 STS 扮演角色获取临时凭证
+func (t *TencentCloudDNS) assumeRole(ctx context.Context) (*STSCredentials, error) {
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = "sts.tencentcloudapi.com"
+	stsClient, err := sts.NewClient(common.NewCredential(t.account.SecretID, t.account.SecretKey), tencentSTSRegion, cpf)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("创建 STS 客户端失败: %v", err)
+	}
+	request := sts.NewAssumeRoleRequest()
+	request.RoleArn = common.StringPtr(t.account.RoleArn)
+	request.RoleSessionName = common.StringPtr("dns_exporter_session")
+	request.DurationSeconds = common.Uint64Ptr(3600) // 1小时
+	if t.externalID != "" {
+		request.ExternalId = common.StringPtr(t.externalID)
+	}
+	response, err := stsClient.AssumeRoleWithContext(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("AssumeRole 失败: %v", err)
+	}
+	if response.Response == nil || response.Response.Credentials == nil {
+		return nil, fmt.Errorf("STS 响应无效")
 	}
-	return &TencentCloudDNS{
-		account: account,
-		client:  client,
+	creds := response.Response.Credentials
+	return &STSCredentials{
+		AccessKeyId:     tea.StringValue(creds.TmpSecretId),
+		AccessKeySecret: tea.StringValue(creds.TmpSecretKey),
+		SecurityToken:   tea.StringValue(creds.Token),
+		Expiration:      time.Unix(tea.Int64Value(response.Response.ExpiredTime), 0),
 	}, nil
 }
 
-// ListDomains 获取域名列表
-func (t *TencentCloudDNS) ListDomains() ([]Domain, error) {
-	tcd, err := NewTencentCloudDNS(public.Account{
-		CloudProvider: t.account.CloudProvider,
-		CloudName:     t.account.CloudName,
-		SecretID:      t.account.SecretID,
-		SecretKey:     t.account.SecretKey,
-	})
+// stsCacheKey 认证相关配置的摘要，密钥、扮演的角色及外部ID任一变化都需要重新获取凭证
+func (t *TencentCloudDNS) stsCacheKey() string {
+	return credentialKey(t.account.SecretID, t.account.SecretKey, t.account.RoleArn, t.externalID)
+}
+
+// cachedCredentials 获取账号缓存的有效凭证，调用方需持有 tencentSTSCredentialsMutex
+func (t *TencentCloudDNS) cachedCredentials(key string) *STSCredentials {
+	cached, ok := tencentSTSCredentials[t.account.CloudName]
+	if !ok || cached.key != key || cached.creds.IsExpired() {
+		return nil
+	}
+	return cached.creds
+}
+
+// releaseTencentCredentials 清理账号缓存的 STS 临时凭证
+func releaseTencentCredentials(cloudName string) {
+	tencentSTSCredentialsMutex.Lock()
+	defer tencentSTSCredentialsMutex.Unlock()
+	delete(tencentSTSCredentials, cloudName)
+}
+
+// getValidCredentials 获取有效的凭证（自动刷新），未配置 roleArn 时返回 nil
+func (t *TencentCloudDNS) getValidCredentials(ctx context.Context) (*STSCredentials, error) {
+	if t.account.RoleArn == "" {
+		return nil, nil
+	}
+	key := t.stsCacheKey()
+
+	tencentSTSCredentialsMutex.RLock()
+	if creds := t.cachedCredentials(key); creds != nil {
+		defer tencentSTSCredentialsMutex.RUnlock()
+		return creds, nil
+	}
+	tencentSTSCredentialsMutex.RUnlock()
+
+	tencentSTSCredentialsMutex.Lock()
+	defer tencentSTSCredentialsMutex.Unlock()
+
+	// 双重检查
+	if creds := t.cachedCredentials(key); creds != nil {
+		return creds, nil
+	}
+
+	newCreds, err := t.assumeRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tencentSTSCredentials[t.account.CloudName] = tencentCachedCredentials{key: key, creds: newCreds}
+	logger.Info(fmt.Sprintf("[ %s_%s ] STS 凭证已刷新，过期时间: %s", t.account.CloudProvider, t.account.CloudName, newCreds.Expiration.Format(time.RFC3339)))
+	return newCreds, nil
+}
+
+// getCredential 获取请求使用的凭证，配置了 roleArn 时使用 STS 临时凭证，否则使用原始凭证
+func (t *TencentCloudDNS) getCredential(ctx context.Context) (common.CredentialIface, error) {
+	creds, err := t.getValidCredentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取STS凭证失败: %v", err)
+	}
+	if creds != nil {
+		return common.NewTokenCredential(creds.AccessKeyId, creds.AccessKeySecret, creds.SecurityToken), nil
+	}
+	return common.NewCredential(t.account.SecretID, t.account.SecretKey), nil
+}
+
+// createDNSClient 创建云解析客户端（支持STS）
+func (t *TencentCloudDNS) createDNSClient(ctx context.Context) (*dnspod.Client, error) {
+	credential, err := t.getCredential(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewTencentClient(credential)
+}
+
+// ListDomains 获取域名列表（公网+内网）
+func (t *TencentCloudDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	dataObj, publicErr := t.listPublicDomains(ctx)
+	if publicErr != nil {
+		logger.Error(fmt.Sprintf("[ %s_%s ] list public domains failed: %v", t.account.CloudProvider, t.account.CloudName, publicErr))
+	}
+	if t.account.EnablePrivateDNS {
+		privateDomains, err := t.listPrivateDomains(ctx)
+		if err != nil {
+			logger.Error(fmt.Sprintf("[ %s_%s ] list private zones failed: %v", t.account.CloudProvider, t.account.CloudName, err))
+			// 公网与内网域名均查询失败时整体失败
+			if publicErr != nil {
+				return nil, publicErr
+			}
+		}
+		dataObj = append(dataObj, privateDomains...)
+	} else if publicErr != nil {
+		return nil, publicErr
+	}
+	// 超时或取消时整体失败，避免返回不完整的数据
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// listPublicDomains 获取云解析中的公网域名列表
+func (t *TencentCloudDNS) listPublicDomains(ctx context.Context) ([]Domain, error) {
+	client, err := t.createDNSClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	t.client = tcd.client
+	t.client = client
 
 	var dataObj []Domain
-	domains, err := t.getDomainList()
+	domains, err := t.getDomainList(ctx)
 	if err != nil {
 		return nil, err
 	}
-	domainNames, err := t.getDomainNameList()
+	domainNames, err := t.getDomainNameList(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +217,7 @@ func (t *TencentCloudDNS) ListDomains() ([]Domain, error) {
 			CloudName:       t.account.CloudName,
 			DomainID:        fmt.Sprintf("%d", tea.Uint64Value(v.DomainId)),
 			DomainName:      tea.StringValue(v.Name),
+			DomainType:      "public",
 			DomainRemark:    tea.StringValue(v.Remark),
 			DomainStatus:    oneStatus(tea.StringValue(v.Status)),
 			CreatedDate:     domainCreateAndExpiryDate.CreatedDate,
@@ -86,56 +228,73 @@ func (t *TencentCloudDNS) ListDomains() ([]Domain, error) {
 	return dataObj, nil
 }
 
-// ListRecords 获取记录列表
-func (t *TencentCloudDNS) ListRecords() ([]Record, error) {
+// ListRecords 获取记录列表（公网+内网）
+func (t *TencentCloudDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var publicDomains, privateDomains []Domain
+	// 按域名类型拆分，内网域名需使用私有域解析接口查询
+	for _, domain := range domains {
+		if domain.DomainType == "private" {
+			privateDomains = append(privateDomains, domain)
+		} else {
+			publicDomains = append(publicDomains, domain)
+		}
+	}
+	dataObj, err := t.listPublicRecords(ctx, publicDomains)
+	if err != nil {
+		return nil, err
+	}
+	if t.account.EnablePrivateDNS && len(privateDomains) > 0 {
+		privateRecords, err := t.listPrivateRecords(ctx, privateDomains)
+		if err != nil {
+			return nil, err
+		}
+		dataObj = append(dataObj, privateRecords...)
+	}
+	return dataObj, nil
+}
+
+// listPublicRecords 获取公网域名的记录列表
+func (t *TencentCloudDNS) listPublicRecords(ctx context.Context, domains []Domain) ([]Record, error) {
 	var (
 		dataObj []Record
-		domains []Domain
 		wg      sync.WaitGroup
 		mu      sync.Mutex
 	)
-	tcd, err := NewTencentCloudDNS(public.Account{
-		CloudProvider: t.account.CloudProvider,
-		CloudName:     t.account.CloudName,
-		SecretID:      t.account.SecretID,
-		SecretKey:     t.account.SecretKey,
-	})
-	if err != nil {
-		return nil, err
-	}
-	t.client = tcd.client
-	rst, err := public.Cache.Get(public.DomainList + "_" + t.account.CloudProvider + "_" + t.account.CloudName)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(rst, &domains)
+	client, err := t.createDNSClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	results := make(map[string][]*dnspod.RecordListItem)
+	t.client = client
+	results := make(map[Domain][]*dnspod.RecordListItem)
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 	for _, domain := range domains {
 		wg.Add(1)
-		go func(domain string) {
+		go func(domain Domain) {
 			defer wg.Done()
-			<-ticker.C
-			records, err := t.getRecordList(domain)
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			records, err := t.getRecordList(ctx, domain.DomainName)
 			if err != nil {
 				logger.Error(fmt.Sprintf("[ %s_%s ] get record list failed: %v", t.account.CloudProvider, t.account.CloudName, err))
 			}
 			mu.Lock()
 			results[domain] = records
 			mu.Unlock()
-		}(domain.DomainName)
+		}(domain)
 	}
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	for domain, records := range results {
 		for _, v := range records {
 			dataObj = append(dataObj, Record{
 				CloudProvider: t.account.CloudProvider,
 				CloudName:     t.account.CloudName,
-				DomainName:    domain,
+				DomainName:    domain.DomainName,
+				DomainType:    domain.DomainType,
 				RecordID:      fmt.Sprintf("%d", tea.Uint64Value(v.RecordId)),
 				RecordType:    tea.StringValue(v.Type),
 				RecordName:    tea.StringValue(v.Name),
@@ -145,7 +304,7 @@ func (t *TencentCloudDNS) ListRecords() ([]Record, error) {
 				RecordStatus:  oneStatus(tea.StringValue(v.Status)),
 				RecordRemark:  tea.StringValue(v.Remark),
 				UpdateTime:    tea.StringValue(v.UpdatedOn),
-				FullRecord:    tea.StringValue(v.Name) + "." + domain,
+				FullRecord:    tea.StringValue(v.Name) + "." + domain.DomainName,
 			})
 		}
 	}
@@ -154,9 +313,9 @@ func (t *TencentCloudDNS) ListRecords() ([]Record, error) {
 
 // https://cloud.tencent.com/document/api/1427/56172
 // GetDomainList 获取云解析中域名列表
-func (t *TencentCloudDNS) getDomainList() ([]*dnspod.DomainListItem, error) {
+func (t *TencentCloudDNS) getDomainList(ctx context.Context) ([]*dnspod.DomainListItem, error) {
 	request := dnspod.NewDescribeDomainListRequest()
-	response, err := t.client.DescribeDomainList(request)
+	response, err := t.client.DescribeDomainListWithContext(ctx, request)
 	if _, ok := err.(*errors.TencentCloudSDKError); ok {
 		return nil, err
 	}
@@ -168,7 +327,7 @@ func (t *TencentCloudDNS) getDomainList() ([]*dnspod.DomainListItem, error) {
 
 // https://cloud.tencent.com/document/api/1427/56166
 // RecordList 域名记录列表
-func (t *TencentCloudDNS) getRecordList(domain string) ([]*dnspod.RecordListItem, error) {
+func (t *TencentCloudDNS) getRecordList(ctx context.Context, domain string) ([]*dnspod.RecordListItem, error) {
 	var (
 		offset uint64 = 0
 		limit  uint64 = 3000
@@ -179,7 +338,7 @@ func (t *TencentCloudDNS) getRecordList(domain string) ([]*dnspod.RecordListItem
 	for {
 		request.Offset = common.Uint64Ptr(offset)
 		request.Limit = common.Uint64Ptr(limit)
-		response, err := t.client.DescribeRecordList(request)
+		response, err := t.client.DescribeRecordListWithContext(ctx, request)
 		if e, ok := err.(*errors.TencentCloudSDKError); ok {
 			if e.Code == "ResourceNotFound.NoDataOfRecord" {
 				return temp, nil
@@ -199,24 +358,26 @@ func (t *TencentCloudDNS) getRecordList(domain string) ([]*dnspod.RecordListItem
 
 // https://cloud.tencent.com/document/api/242/48941
 // getDomainNameList 获取域名列表(与云解析的域名列表注意区分)
-func (t *TencentCloudDNS) getDomainNameList() ([]*domain.DomainList, error) {
+func (t *TencentCloudDNS) getDomainNameList(ctx context.Context) ([]*domain.DomainList, error) {
 	var (
 		offset uint64 = 0
 		limit  uint64 = 100
 		temp   []*domain.DomainList
 	)
-	cpf := profile.NewClientProfile()
-	cpf.HttpProfile.Endpoint = "domain.tencentcloudapi.com"
-	client, _ := domain.NewClient(common.NewCredential(
-		t.account.SecretID,
-		t.account.SecretKey,
-	), "", cpf)
+	credential, err := t.getCredential(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewTencentDomainClient(credential)
+	if err != nil {
+		return nil, err
+	}
 
 	request := domain.NewDescribeDomainNameListRequest()
 	for {
 		request.Offset = common.Uint64Ptr(offset)
 		request.Limit = common.Uint64Ptr(limit)
-		response, err := client.DescribeDomainNameList(request)
+		response, err := client.DescribeDomainNameListWithContext(ctx, request)
 		if _, ok := err.(*errors.TencentCloudSDKError); ok {
 			return nil, err
 		}
@@ -245,3 +406,210 @@ func (t *TencentCloudDNS) getDomainCreateAndExpiryDate(domainList []*domain.Doma
 	}
 	return
 }
+
+// tencentPrivateZone 私有域信息
+// 私有域解析的 SDK 包（tencentcloud/privatedns/v20201028）尚未引入依赖，暂通过通用客户端调用，
+// 以下结构体的字段与该包中的 PrivateZone、PrivateZoneRecord 保持一致，引入后可直接替换为类型化客户端
+type tencentPrivateZone struct {
+	ZoneId    string `json:"ZoneId"`
+	Domain    string `json:"Domain"`
+	CreatedOn string `json:"CreatedOn"`
+	Remark    string `json:"Remark"`
+	Status    string `json:"Status"`
+	VpcSet    []struct {
+		UniqVpcId string `json:"UniqVpcId"`
+		Region    string `json:"Region"`
+	} `json:"VpcSet"`
+	AccountVpcSet []struct {
+		Uin       string `json:"Uin"`
+		UniqVpcId string `json:"UniqVpcId"`
+		Region    string `json:"Region"`
+	} `json:"AccountVpcSet"`
+}
+
+// tencentPrivateZoneRecord 私有域解析记录
+type tencentPrivateZoneRecord struct {
+	RecordId    string `json:"RecordId"`
+	SubDomain   string `json:"SubDomain"`
+	RecordType  string `json:"RecordType"`
+	RecordValue string `json:"RecordValue"`
+	TTL         int64  `json:"TTL"`
+	Status      string `json:"Status"`
+	Weight      *int64 `json:"Weight"`
+	UpdatedOn   string `json:"UpdatedOn"`
+	Extra       string `json:"Extra"`
+}
+
+type tencentPrivateZoneListResponse struct {
+	*tchttp.BaseResponse
+	Response *struct {
+		TotalCount     int64                 `json:"TotalCount"`
+		PrivateZoneSet []*tencentPrivateZone `json:"PrivateZoneSet"`
+	} `json:"Response"`
+}
+
+type tencentPrivateZoneRecordListResponse struct {
+	*tchttp.BaseResponse
+	Response *struct {
+		TotalCount int64                       `json:"TotalCount"`
+		RecordSet  []*tencentPrivateZoneRecord `json:"RecordSet"`
+	} `json:"Response"`
+}
+
+// createPrivateDNSClient 创建私有域解析客户端（支持STS）
+func (t *TencentCloudDNS) createPrivateDNSClient(ctx context.Context) (*common.Client, error) {
+	credential, err := t.getCredential(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = "privatedns.tencentcloudapi.com"
+	return common.NewCommonClient(credential, tencentPrivateDNSRegion, cpf), nil
+}
+
+// sendPrivateDNSRequest 调用私有域解析接口
+func sendPrivateDNSRequest(ctx context.Context, client *common.Client, action string, params map[string]interface{}, response tchttp.Response) error {
+	request := tchttp.NewCommonRequest("privatedns", "2020-10-28", action)
+	request.SetContext(ctx)
+	if err := request.SetActionParameters(params); err != nil {
+		return err
+	}
+	return client.Send(request, response)
+}
+
+// https://cloud.tencent.com/document/api/1338/55937
+// listPrivateDomains 获取私有域列表
+func (t *TencentCloudDNS) listPrivateDomains(ctx context.Context) ([]Domain, error) {
+	client, err := t.createPrivateDNSClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		offset  int64 = 0
+		limit   int64 = 100
+		dataObj []Domain
+	)
+	for {
+		response := &tencentPrivateZoneListResponse{BaseResponse: &tchttp.BaseResponse{}}
+		err := sendPrivateDNSRequest(ctx, client, "DescribePrivateZoneList", map[string]interface{}{
+			"Offset": offset,
+			"Limit":  limit,
+		}, response)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range response.Response.PrivateZoneSet {
+			// 关联的 VPC，跨账号关联的 VPC 带上所属账号
+			var vpcs []string
+			for _, vpc := range v.VpcSet {
+				vpcs = append(vpcs, fmt.Sprintf("%s:%s", vpc.UniqVpcId, vpc.Region))
+			}
+			for _, vpc := range v.AccountVpcSet {
+				vpcs = append(vpcs, fmt.Sprintf("%s/%s:%s", vpc.Uin, vpc.UniqVpcId, vpc.Region))
+			}
+			dataObj = append(dataObj, Domain{
+				CloudProvider: t.account.CloudProvider,
+				CloudName:     t.account.CloudName,
+				DomainID:      v.ZoneId,
+				DomainName:    v.Domain,
+				DomainType:    "private",
+				DomainRemark:  v.Remark,
+				DomainStatus:  oneStatus(v.Status),
+				DomainVPCs:    strings.Join(vpcs, ","),
+				CreatedDate:   carbon.Parse(v.CreatedOn).ToDateTimeString(),
+			})
+		}
+		offset += limit
+		if len(response.Response.PrivateZoneSet) == 0 || offset >= response.Response.TotalCount {
+			break
+		}
+	}
+	return dataObj, nil
+}
+
+// listPrivateRecords 获取私有域的记录列表
+func (t *TencentCloudDNS) listPrivateRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var (
+		dataObj []Record
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+	)
+	client, err := t.createPrivateDNSClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain Domain) {
+			defer wg.Done()
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			rds, err := t.getPrivateRecordList(ctx, client, domain.DomainID)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[ %s_%s ] get private record list of %s failed: %v", t.account.CloudProvider, t.account.CloudName, domain.DomainName, err))
+				return
+			}
+			var records []Record
+			for _, v := range rds {
+				var weight string
+				if v.Weight != nil {
+					weight = fmt.Sprintf("%d", *v.Weight)
+				}
+				records = append(records, Record{
+					CloudProvider: t.account.CloudProvider,
+					CloudName:     t.account.CloudName,
+					DomainName:    domain.DomainName,
+					DomainType:    domain.DomainType,
+					RecordID:      v.RecordId,
+					RecordType:    v.RecordType,
+					RecordName:    v.SubDomain,
+					RecordValue:   v.RecordValue,
+					RecordTTL:     fmt.Sprintf("%d", v.TTL),
+					RecordWeight:  weight,
+					RecordStatus:  oneStatus(v.Status),
+					RecordRemark:  v.Extra,
+					UpdateTime:    carbon.Parse(v.UpdatedOn).ToDateTimeString(),
+					FullRecord:    v.SubDomain + "." + domain.DomainName,
+				})
+			}
+			mu.Lock()
+			dataObj = append(dataObj, records...)
+			mu.Unlock()
+		}(domain)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// https://cloud.tencent.com/document/api/1338/55938
+// getPrivateRecordList 分页获取私有域的全部解析记录
+func (t *TencentCloudDNS) getPrivateRecordList(ctx context.Context, client *common.Client, zoneID string) ([]*tencentPrivateZoneRecord, error) {
+	var (
+		offset int64 = 0
+		limit  int64 = 200
+		temp   []*tencentPrivateZoneRecord
+	)
+	for {
+		response := &tencentPrivateZoneRecordListResponse{BaseResponse: &tchttp.BaseResponse{}}
+		err := sendPrivateDNSRequest(ctx, client, "DescribePrivateZoneRecordList", map[string]interface{}{
+			"ZoneId": zoneID,
+			"Offset": offset,
+			"Limit":  limit,
+		}, response)
+		if err != nil {
+			return nil, err
+		}
+		temp = append(temp, response.Response.RecordSet...)
+		offset += limit
+		if len(response.Response.RecordSet) == 0 || offset >= response.Response.TotalCount {
+			break
+		}
+	}
+	return temp, nil
+}
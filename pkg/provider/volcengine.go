@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	"github.com/golang-module/carbon/v2"
+	"github.com/volcengine/volcengine-go-sdk/service/dns"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/session"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/universal"
+)
+
+// VolcengineDNS 火山引擎云解析 DNS（TrafficRoute）
+type VolcengineDNS struct {
+	account public.Account
+}
+
+// volcengineRegion 云解析为全局服务，固定使用 cn-north-1 签名
+const volcengineRegion = "cn-north-1"
+
+// 域名注册服务的开放接口，SDK 未提供对应的服务包，通过通用客户端调用
+const (
+	volcengineDomainService = "domain_openapi"
+	volcengineDomainVersion = "2022-12-12"
+)
+
+// volcengineRegistrarLogged 已输出过域名注册服务查询失败日志的账号，每个账号只记录一次，账号变更后重新记录
+var volcengineRegistrarLogged sync.Map
+
+// volcengineRegisteredDomain 在火山引擎注册的域名
+type volcengineRegisteredDomain struct {
+	DomainName   string `json:"DomainName"`
+	RegisterTime string `json:"RegisterTime"`
+	ExpireTime   string `json:"ExpireTime"`
+}
+
+// volcengineDomainListOutput 已注册域名列表
+type volcengineDomainListOutput struct {
+	Total   int                          `json:"Total"`
+	Domains []volcengineRegisteredDomain `json:"Domains"`
+}
+
+// newVolcengineSession 使用 AK/SK 初始化会话
+func newVolcengineSession(secretID, secretKey string) (*session.Session, error) {
+	if secretID == "" || secretKey == "" {
+		return nil, fmt.Errorf("missing volcengine access key or secret key")
+	}
+	config := volcengine.NewConfig().
+		WithRegion(volcengineRegion).
+		WithCredentials(credentials.NewStaticCredentials(secretID, secretKey, ""))
+	return session.NewSession(config)
+}
+
+// NewVolcengineClient 初始化客户端
+func NewVolcengineClient(secretID, secretKey string) (*dns.DNS, error) {
+	sess, err := newVolcengineSession(secretID, secretKey)
+	if err != nil {
+		return nil, err
+	}
+	return dns.New(sess), nil
+}
+
+// ListDomains 获取域名列表
+// 注：云解析接口返回的到期时间为解析套餐的到期时间，域名的注册及到期时间通过域名注册服务查询，未在火山引擎注册的域名不填充
+func (v *VolcengineDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	sess, err := newVolcengineSession(v.account.SecretID, v.account.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	client := dns.New(sess)
+	registered, err := v.getRegisteredDomains(ctx, sess)
+	if err != nil {
+		// 缺少域名服务权限时每次采集都会失败，每个账号只记录一次
+		if _, logged := volcengineRegistrarLogged.LoadOrStore(v.account.CloudName, struct{}{}); !logged {
+			logger.Warning(fmt.Sprintf("[ %s_%s ] list registered domains failed, domain expiry is left unknown: %v", v.account.CloudProvider, v.account.CloudName, err))
+		}
+	}
+	var dataObj []Domain
+	for page := int32(1); ; page++ {
+		rst, err := client.ListZonesWithContext(ctx, &dns.ListZonesInput{
+			PageNumber: volcengine.Int32(page),
+			PageSize:   volcengine.Int32(100),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, z := range rst.Zones {
+			domain := Domain{
+				CloudProvider: v.account.CloudProvider,
+				CloudName:     v.account.CloudName,
+				DomainID:      strconv.Itoa(int(volcengine.Int32Value(z.ZID))),
+				DomainName:    volcengine.StringValue(z.ZoneName),
+				DomainType:    "public",
+				DomainRemark:  volcengine.StringValue(z.Remark),
+				DomainStatus:  "enable",
+				CreatedDate:   carbon.Parse(volcengine.StringValue(z.CreatedAt)).ToDateTimeString(),
+			}
+			if r, ok := registered[domain.DomainName]; ok {
+				if created := carbon.Parse(r.RegisterTime); created.IsValid() {
+					domain.CreatedDate = created.ToDateTimeString()
+				}
+				if expiry := carbon.Parse(r.ExpireTime); expiry.IsValid() {
+					domain.ExpiryDate = expiry.ToDateTimeString()
+					domain.DaysUntilExpiry = carbon.Now().DiffInDays(expiry)
+				}
+			}
+			dataObj = append(dataObj, domain)
+		}
+		if len(rst.Zones) == 0 || len(dataObj) >= int(volcengine.Int32Value(rst.Total)) {
+			break
+		}
+	}
+	return dataObj, nil
+}
+
+// ListRecords 获取记录列表
+func (v *VolcengineDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var (
+		dataObj []Record
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+	)
+	client, err := NewVolcengineClient(v.account.SecretID, v.account.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain Domain) {
+			defer wg.Done()
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			rds, err := v.getRecordList(ctx, client, domain.DomainID)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[ %s_%s ] get record list of %s failed: %v", v.account.CloudProvider, v.account.CloudName, domain.DomainName, err))
+				return
+			}
+			var records []Record
+			for _, r := range rds {
+				status := "enable"
+				if !volcengine.BoolValue(r.Enable) {
+					status = "disable"
+				}
+				records = append(records, Record{
+					CloudProvider: v.account.CloudProvider,
+					CloudName:     v.account.CloudName,
+					DomainName:    domain.DomainName,
+					DomainType:    domain.DomainType,
+					RecordID:      volcengine.StringValue(r.RecordID),
+					RecordType:    volcengine.StringValue(r.Type),
+					RecordName:    volcengine.StringValue(r.Host),
+					RecordValue:   volcengine.StringValue(r.Value),
+					RecordTTL:     strconv.Itoa(int(volcengine.Int32Value(r.TTL))),
+					RecordWeight:  strconv.Itoa(int(volcengine.Int32Value(r.Weight))),
+					RecordLine:    volcengine.StringValue(r.Line),
+					RecordStatus:  status,
+					RecordRemark:  volcengine.StringValue(r.Remark),
+					UpdateTime:    carbon.Parse(volcengine.StringValue(r.UpdatedAt)).ToDateTimeString(),
+					FullRecord:    volcengine.StringValue(r.FQDN),
+				})
+			}
+			mu.Lock()
+			dataObj = append(dataObj, records...)
+			mu.Unlock()
+		}(domain)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// getRegisteredDomains 分页查询在火山引擎注册的域名，以域名为键
+func (v *VolcengineDNS) getRegisteredDomains(ctx context.Context, sess *session.Session) (map[string]volcengineRegisteredDomain, error) {
+	client := universal.New(sess)
+	info := universal.RequestUniversal{
+		ServiceName: volcengineDomainService,
+		Action:      "ListDomains",
+		Version:     volcengineDomainVersion,
+		HttpMethod:  universal.GET,
+	}
+	domains := make(map[string]volcengineRegisteredDomain)
+	for page := 1; ; page++ {
+		// 通用客户端不支持 context，通过 callWithContext 保证超时后及时返回
+		rst, err := callWithContext(ctx, func() (*volcengineDomainListOutput, error) {
+			output := &volcengineDomainListOutput{}
+			err := client.DoCallWithType(info, &map[string]interface{}{
+				"PageNumber": page,
+				"PageSize":   100,
+			}, output)
+			return output, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range rst.Domains {
+			domains[d.DomainName] = d
+		}
+		if len(rst.Domains) == 0 || len(domains) >= rst.Total {
+			break
+		}
+	}
+	return domains, nil
+}
+
+// getRecordList 分页查询域名下的全部解析记录
+func (v *VolcengineDNS) getRecordList(ctx context.Context, client *dns.DNS, zoneID string) ([]*dns.RecordForListRecordsOutput, error) {
+	zid, err := strconv.ParseInt(zoneID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zone id %s: %v", zoneID, err)
+	}
+	var records []*dns.RecordForListRecordsOutput
+	for page := int32(1); ; page++ {
+		rst, err := client.ListRecordsWithContext(ctx, &dns.ListRecordsInput{
+			ZID:        volcengine.Int64(zid),
+			PageNumber: volcengine.Int32(page),
+			PageSize:   volcengine.Int32(500),
+		})
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rst.Records...)
+		if len(rst.Records) == 0 || len(records) >= int(volcengine.Int32Value(rst.TotalCount)) {
+			break
+		}
+	}
+	return records, nil
+}
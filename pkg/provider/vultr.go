@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/dnslib/vultr"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	"github.com/golang-module/carbon/v2"
+)
+
+// VultrDNS Vultr DNS
+type VultrDNS struct {
+	account public.Account
+}
+
+// ListDomains 获取域名列表
+func (v *VultrDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	client, err := vultr.NewClient(v.account.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		dataObj []Domain
+		cursor  string
+	)
+	for {
+		rst, err := client.Domains.List(ctx, vultr.NewPageOption(cursor, 500))
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range rst.Domains {
+			dataObj = append(dataObj, Domain{
+				CloudProvider: v.account.CloudProvider,
+				CloudName:     v.account.CloudName,
+				DomainID:      d.Domain,
+				DomainName:    d.Domain,
+				DomainType:    "public",
+				DomainStatus:  "enable",
+				CreatedDate:   carbon.Parse(d.DateCreated).ToDateTimeString(),
+			})
+		}
+		if len(rst.Domains) == 0 || rst.Meta.Links.Next == "" {
+			break
+		}
+		cursor = rst.Meta.Links.Next
+	}
+	return dataObj, nil
+}
+
+// ListRecords 获取记录列表
+func (v *VultrDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var (
+		dataObj []Record
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+	)
+	client, err := vultr.NewClient(v.account.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for _, domain := range domains {
+		wg.Add(1)
+		go func(domain Domain) {
+			defer wg.Done()
+			if err := waitTick(ctx, ticker); err != nil {
+				return
+			}
+			rds, err := v.getRecordList(ctx, client, domain.DomainName)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[ %s_%s ] get record list of %s failed: %v", v.account.CloudProvider, v.account.CloudName, domain.DomainName, err))
+				return
+			}
+			var records []Record
+			for _, r := range rds {
+				recordName, fullRecord := "@", domain.DomainName
+				if r.Name != "" {
+					recordName, fullRecord = r.Name, r.Name+"."+domain.DomainName
+				}
+				// 优先级单独返回，按区域文件格式拼接到记录值前，与 Cloudflare 保持一致；SRV 的记录值已包含权重与端口
+				value, weight := r.Data, ""
+				switch r.Type {
+				case "MX":
+					value = fmt.Sprintf("%d %s", r.Priority, r.Data)
+				case "SRV":
+					value = fmt.Sprintf("%d %s", r.Priority, r.Data)
+					if fields := strings.Fields(r.Data); len(fields) == 3 {
+						weight = fields[0]
+					}
+				}
+				records = append(records, Record{
+					CloudProvider: v.account.CloudProvider,
+					CloudName:     v.account.CloudName,
+					DomainName:    domain.DomainName,
+					DomainType:    domain.DomainType,
+					RecordID:      r.ID,
+					RecordType:    r.Type,
+					RecordName:    recordName,
+					RecordValue:   value,
+					RecordTTL:     strconv.Itoa(r.TTL),
+					RecordWeight:  weight,
+					RecordStatus:  "enable",
+					FullRecord:    fullRecord,
+				})
+			}
+			mu.Lock()
+			dataObj = append(dataObj, records...)
+			mu.Unlock()
+		}(domain)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return dataObj, nil
+}
+
+// getRecordList 按游标分页查询域名下的全部解析记录
+func (v *VultrDNS) getRecordList(ctx context.Context, client *vultr.Client, domain string) ([]vultr.Record, error) {
+	var (
+		records []vultr.Record
+		cursor  string
+	)
+	for {
+		rst, err := client.Records.List(ctx, vultr.NewPageOption(cursor, 500), domain)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rst.Records...)
+		if len(rst.Records) == 0 || rst.Meta.Links.Next == "" {
+			break
+		}
+		cursor = rst.Meta.Links.Next
+	}
+	return records, nil
+}
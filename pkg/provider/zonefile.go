@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	"github.com/miekg/dns"
+)
+
+// ZoneFileDNS 从 BIND 区域文件（RFC 1035 master file）采集解析记录
+// 每个文件对应一个区域，区域名为文件中 SOA 记录的名称
+type ZoneFileDNS struct {
+	account public.Account
+	files   []string // 区域文件路径，支持 glob 通配符
+}
+
+// ListDomains 获取域名列表，即匹配到的每个区域文件
+func (z *ZoneFileDNS) ListDomains(ctx context.Context) ([]Domain, error) {
+	paths, err := z.expandFiles()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		dataObj []Domain
+		lastErr error
+	)
+	for _, path := range paths {
+		domain, err := z.parseDomain(path)
+		if err != nil {
+			lastErr = err
+			logger.Error(fmt.Sprintf("[ %s_%s ] parse zone file %s failed: %v", z.account.CloudProvider, z.account.CloudName, path, err))
+			continue
+		}
+		dataObj = append(dataObj, domain)
+	}
+	// 所有文件均解析失败时整体失败
+	if len(dataObj) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return dataObj, nil
+}
+
+// ListRecords 获取记录列表，DomainID 为区域文件路径
+func (z *ZoneFileDNS) ListRecords(ctx context.Context, domains []Domain) ([]Record, error) {
+	var dataObj []Record
+	for _, domain := range domains {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		rrs, err := parseZoneFile(domain.DomainID, domain.DomainName)
+		if err != nil {
+			logger.Error(fmt.Sprintf("[ %s_%s ] parse zone file %s failed: %v", z.account.CloudProvider, z.account.CloudName, domain.DomainID, err))
+			continue
+		}
+		dataObj = append(dataObj, dedupeRecords(convertRRs(z.account, domain, rrs))...)
+	}
+	return dataObj, nil
+}
+
+// dedupeRecords 去掉完全相同的记录（如区域文件中重复书写或经 $INCLUDE 重复引入），只保留第一条，避免产生重复的指标标签
+func dedupeRecords(records []Record) []Record {
+	seen := make(map[string]bool, len(records))
+	rst := records[:0]
+	for _, r := range records {
+		if seen[r.RecordID] {
+			continue
+		}
+		seen[r.RecordID] = true
+		rst = append(rst, r)
+	}
+	return rst
+}
+
+// expandFiles 展开配置中的文件路径与 glob 通配符，并去重
+func (z *ZoneFileDNS) expandFiles() ([]string, error) {
+	if len(z.files) == 0 {
+		return nil, errors.New("no zone file configured")
+	}
+	var paths []string
+	seen := make(map[string]bool)
+	for _, pattern := range z.files {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zone file pattern %s: %v", pattern, err)
+		}
+		if len(matches) == 0 {
+			logger.Warning(fmt.Sprintf("[ %s_%s ] no zone file matches %s", z.account.CloudProvider, z.account.CloudName, pattern))
+		}
+		for _, path := range matches {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths, nil
+}
+
+// parseDomain 解析区域文件，以 SOA 记录的名称作为域名
+// 区域文件中没有域名的创建时间，文件修改时间会随每次编辑变化，因此不填充 CreatedDate
+func (z *ZoneFileDNS) parseDomain(path string) (Domain, error) {
+	origin := zoneFileOrigin(path)
+	rrs, err := parseZoneFile(path, origin)
+	if err != nil {
+		return Domain{}, err
+	}
+	for _, rr := range rrs {
+		if rr.Header().Rrtype == dns.TypeSOA {
+			origin = strings.TrimSuffix(rr.Header().Name, ".")
+			break
+		}
+	}
+	return Domain{
+		CloudProvider: z.account.CloudProvider,
+		CloudName:     z.account.CloudName,
+		DomainID:      path,
+		DomainName:    origin,
+		DomainType:    "public",
+		DomainStatus:  "enable",
+	}, nil
+}
+
+// parseZoneFile 解析区域文件中的全部记录，origin 为文件未声明 $ORIGIN 时使用的默认区域
+// $INCLUDE 的相对路径以当前文件所在目录为基准
+func parseZoneFile(path, origin string) ([]dns.RR, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	zp := dns.NewZoneParser(f, dns.Fqdn(origin), path)
+	zp.SetIncludeAllowed(true)
+	var rrs []dns.RR
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		rrs = append(rrs, rr)
+	}
+	if err := zp.Err(); err != nil {
+		return nil, err
+	}
+	return rrs, nil
+}
+
+// zoneFileOrigin 根据常见的 BIND 命名习惯（db.example.com、example.com.zone、example.com.db）从文件名推断默认区域
+func zoneFileOrigin(path string) string {
+	name := filepath.Base(path)
+	name = strings.TrimPrefix(name, "db.")
+	name = strings.TrimSuffix(name, ".zone")
+	name = strings.TrimSuffix(name, ".db")
+	return name
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public"
+)
+
+func TestZoneFileListRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string   // 文件名 -> 内容
+		pattern string              // 相对于临时目录的路径或 glob
+		want    map[string][]string // 域名 -> "记录名 类型 TTL 记录值"
+	}{
+		{
+			name: "origin directive",
+			files: map[string]string{
+				"example.com.zone": `$ORIGIN example.com.
+$TTL 3600
+@ IN SOA ns1 admin 1 7200 3600 1209600 300
+@ IN NS ns1
+www IN A 192.0.2.1
+$ORIGIN sub.example.com.
+app IN A 192.0.2.2
+`,
+			},
+			pattern: "example.com.zone",
+			want: map[string][]string{
+				"example.com": {
+					"@ NS 3600 ns1.example.com.",
+					"@ SOA 3600 ns1.example.com. admin.example.com. 1 7200 3600 1209600 300",
+					"app.sub A 3600 192.0.2.2",
+					"www A 3600 192.0.2.1",
+				},
+			},
+		},
+		{
+			name: "origin from file name with relative owners",
+			files: map[string]string{
+				"db.example.org": `$TTL 600
+@ IN SOA ns1.example.org. admin.example.org. 1 7200 3600 1209600 300
+  IN MX 10 mail
+mail IN A 192.0.2.10
+     IN AAAA 2001:db8::10
+ftp.example.org. IN CNAME mail
+`,
+			},
+			pattern: "db.example.org",
+			want: map[string][]string{
+				"example.org": {
+					"@ MX 600 10 mail.example.org.",
+					"@ SOA 600 ns1.example.org. admin.example.org. 1 7200 3600 1209600 300",
+					"ftp CNAME 600 mail.example.org.",
+					"mail A 600 192.0.2.10",
+					"mail AAAA 600 2001:db8::10",
+				},
+			},
+		},
+		{
+			name: "ttl defaults",
+			files: map[string]string{
+				"example.net.zone": `$ORIGIN example.net.
+@ 86400 IN SOA ns1 admin 1 7200 3600 1209600 300
+a IN A 192.0.2.1
+$TTL 300
+b IN A 192.0.2.2
+c 60 IN A 192.0.2.3
+`,
+			},
+			pattern: "example.net.zone",
+			want: map[string][]string{
+				"example.net": {
+					"@ SOA 86400 ns1.example.net. admin.example.net. 1 7200 3600 1209600 300",
+					"a A 86400 192.0.2.1",
+					"b A 300 192.0.2.2",
+					"c A 60 192.0.2.3",
+				},
+			},
+		},
+		{
+			name: "include relative to zone file",
+			files: map[string]string{
+				"example.com.zone": `$ORIGIN example.com.
+$TTL 3600
+@ IN SOA ns1 admin 1 7200 3600 1209600 300
+$INCLUDE includes/hosts.inc
+$INCLUDE includes/mail.inc mail.example.com.
+`,
+				"includes/hosts.inc": "www IN A 192.0.2.1\n",
+				"includes/mail.inc":  "@ IN A 192.0.2.25\n",
+			},
+			pattern: "example.com.zone",
+			want: map[string][]string{
+				"example.com": {
+					"@ SOA 3600 ns1.example.com. admin.example.com. 1 7200 3600 1209600 300",
+					"mail A 3600 192.0.2.25",
+					"www A 3600 192.0.2.1",
+				},
+			},
+		},
+		{
+			name: "glob matches several files",
+			files: map[string]string{
+				"zones/a.example.zone": `$ORIGIN a.example.
+$TTL 300
+@ IN SOA ns1 admin 1 7200 3600 1209600 300
+www IN A 192.0.2.1
+`,
+				"zones/b.example.zone": `$ORIGIN b.example.
+$TTL 300
+@ IN SOA ns1 admin 1 7200 3600 1209600 300
+www IN A 192.0.2.2
+`,
+				"zones/readme.txt": "not a zone file\n",
+			},
+			pattern: "zones/*.zone",
+			want: map[string][]string{
+				"a.example": {
+					"@ SOA 300 ns1.a.example. admin.a.example. 1 7200 3600 1209600 300",
+					"www A 300 192.0.2.1",
+				},
+				"b.example": {
+					"@ SOA 300 ns1.b.example. admin.b.example. 1 7200 3600 1209600 300",
+					"www A 300 192.0.2.2",
+				},
+			},
+		},
+		{
+			name: "duplicate records are collapsed",
+			files: map[string]string{
+				"example.com.zone": `$ORIGIN example.com.
+$TTL 300
+@ IN SOA ns1 admin 1 7200 3600 1209600 300
+www IN A 192.0.2.1
+www.example.com. IN A 192.0.2.1
+$INCLUDE hosts.inc
+`,
+				"hosts.inc": "www IN A 192.0.2.1\nwww IN A 192.0.2.2\n",
+			},
+			pattern: "example.com.zone",
+			want: map[string][]string{
+				"example.com": {
+					"@ SOA 300 ns1.example.com. admin.example.com. 1 7200 3600 1209600 300",
+					"www A 300 192.0.2.1",
+					"www A 300 192.0.2.2",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			z := &ZoneFileDNS{
+				account: public.Account{CloudProvider: public.ZoneFileDnsProvider, CloudName: "zones"},
+				files:   []string{filepath.Join(dir, tt.pattern)},
+			}
+			ctx := context.Background()
+			domains, err := z.ListDomains(ctx)
+			if err != nil {
+				t.Fatalf("ListDomains() error = %v", err)
+			}
+			records, err := z.ListRecords(ctx, domains)
+			if err != nil {
+				t.Fatalf("ListRecords() error = %v", err)
+			}
+			got := make(map[string][]string)
+			ids := make(map[string]bool)
+			for _, r := range records {
+				got[r.DomainName] = append(got[r.DomainName], r.RecordName+" "+r.RecordType+" "+r.RecordTTL+" "+r.RecordValue)
+				if ids[r.RecordID] {
+					t.Errorf("duplicate record id %s for %+v", r.RecordID, r)
+				}
+				ids[r.RecordID] = true
+			}
+			for _, v := range got {
+				sort.Strings(v)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/charmbracelet/log"
@@ -12,15 +13,25 @@ var (
 	once   sync.Once
 )
 
-func InitLogger(level string) {
+// InitLogger 初始化日志，level 支持 debug/info/warn/error，format 支持 text/json/logfmt
+func InitLogger(level, format string) {
 	once.Do(func() {
 		Logger = log.NewWithOptions(os.Stderr, log.Options{ReportTimestamp: true})
 	})
-	if level == "debug" {
-		Logger.SetLevel(log.DebugLevel)
-	} else {
-		Logger.SetLevel(log.InfoLevel)
+	switch strings.ToLower(format) {
+	case "json":
+		Logger.SetFormatter(log.JSONFormatter)
+	case "logfmt":
+		Logger.SetFormatter(log.LogfmtFormatter)
+	default:
+		Logger.SetFormatter(log.TextFormatter)
 	}
+	lvl, err := log.ParseLevel(level)
+	if err != nil {
+		lvl = log.InfoLevel
+		Logger.Warn("invalid log level, fallback to info", "level", level)
+	}
+	Logger.SetLevel(lvl)
 }
 
 func Info(args ...interface{}) {
@@ -1,21 +1,27 @@
 package public
 
 import (
-	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"os"
 	"sync"
 	"time"
 
-	"github.com/allegro/bigcache/v3"
 	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
-	"github.com/rs/xid"
+	bolt "go.etcd.io/bbolt"
 
 	"gopkg.in/yaml.v2"
 )
 
-// InitSvc 初始化服务
-func InitSvc() {
-	LoadConfig()
+// InitSvc 初始化服务，configFile 为配置文件路径
+func InitSvc(configFile string) {
+	config, err := LoadConfig(configFile)
+	if err != nil {
+		logger.Fatal("load config file failed: ", err)
+	}
+	ConfigFile = configFile
+	SetConfig(config)
 	InitCache()
 }
 
@@ -23,23 +29,48 @@ const (
 	// Custom
 	CustomRecords string = "custom_records"
 	// Cloud Providers
-	TencentDnsProvider    string = "tencent"
-	AliyunDnsProvider     string = "aliyun"
-	GodaddyDnsProvider    string = "godaddy"
-	DNSLaDnsProvider      string = "dnsla"
-	AmazonDnsProvider     string = "amazon"
-	CloudFlareDnsProvider string = "cloudflare"
+	TencentDnsProvider      string = "tencent"
+	AliyunDnsProvider       string = "aliyun"
+	GodaddyDnsProvider      string = "godaddy"
+	DNSLaDnsProvider        string = "dnsla"
+	AmazonDnsProvider       string = "amazon"
+	CloudFlareDnsProvider   string = "cloudflare"
+	HuaweiDnsProvider       string = "huawei"
+	GoogleDnsProvider       string = "google"
+	AzureDnsProvider        string = "azure"
+	AXFRDnsProvider         string = "axfr"
+	ZoneFileDnsProvider     string = "zonefile"
+	PowerDnsProvider        string = "powerdns"
+	DigitalOceanDnsProvider string = "digitalocean"
+	LinodeDnsProvider       string = "linode"
+	VultrDnsProvider        string = "vultr"
+	VolcengineDnsProvider   string = "volcengine"
 	// Metrics Name
 	DomainList     string = "domain_list"
 	RecordList     string = "record_list"
 	RecordCertInfo string = "record_cert_info"
+	// Collect Health Metrics Name
+	CollectLastSuccessTimestamp string = "collect_last_success_timestamp_seconds"
+	CollectDuration             string = "collect_duration_seconds"
+	CollectSuccess              string = "collect_success"
+	CollectDomains              string = "collect_domains"
+	CollectRecords              string = "collect_records"
+	CollectFailures             string = "collect_failures_total"
+	CollectAPIErrors            string = "collect_api_errors_total"
+	// Data Freshness Metrics Name
+	CollectedAtTimestamp string = "collected_at_timestamp_seconds"
+	DataStale            string = "data_stale"
+	// Default Cache Max Age
+	DefaultCacheMaxAge     string = "1h"
+	DefaultCertCacheMaxAge string = "25h"
 )
 
 var (
-	once      sync.Once
-	Config    *Configuration
-	Cache     *bigcache.BigCache
-	CertCache *bigcache.BigCache
+	ConfigFile  string // 配置文件路径，热加载时重新读取
+	config      *Configuration
+	configMutex sync.RWMutex
+	Cache       *Store // 域名及解析记录缓存
+	CertCache   *Store // 证书信息缓存
 )
 
 type Account struct {
@@ -53,42 +84,129 @@ type Account struct {
 
 // Config 表示配置文件的结构
 type Configuration struct {
-	CustomRecords  []string `yaml:"custom_records"`
+	CustomRecords []string `yaml:"custom_records"`
+	Schedule      Schedule `yaml:"schedule"`
+	Cache         struct {
+		MaxAge     string `yaml:"max_age"`      // 域名及解析记录的最大保留时间，刷新失败时在此时间内继续提供旧数据
+		CertMaxAge string `yaml:"cert_max_age"` // 证书信息的最大保留时间
+	} `yaml:"cache"`
 	CloudProviders map[string]struct {
 		Accounts []map[string]string `yaml:"accounts"`
 	} `yaml:"cloud_providers"`
 }
 
-// LoadConfig 加载配置
-func LoadConfig() *Configuration {
-	once.Do(func() {
-		Config = &Configuration{}
-		data, err := os.ReadFile("config.yaml")
-		if err != nil {
-			logger.Fatal("read config file failed: ", err)
-		}
-		err = yaml.Unmarshal(data, &Config)
-		if err != nil {
-			logger.Fatal("unmarshal config file failed: ", err)
+// LoadConfig 读取并校验配置文件，不会修改当前生效的配置
+func LoadConfig(configFile string) (*Configuration, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("read config file failed: %v", err)
+	}
+	c := &Configuration{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("unmarshal config file failed: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate 校验配置，同一服务商下的账号名不能为空且不能重复，采集周期需合法
+func (c *Configuration) Validate() error {
+	if _, err := c.ScheduleOf(nil); err != nil {
+		return fmt.Errorf("schedule: %v", err)
+	}
+	if _, _, err := c.CacheMaxAge(); err != nil {
+		return fmt.Errorf("cache: %v", err)
+	}
+	for cloudProvider, accounts := range c.CloudProviders {
+		names := make(map[string]bool)
+		for i, account := range accounts.Accounts {
+			name := account["name"]
+			if name == "" {
+				return fmt.Errorf("cloud provider %s: account #%d has no name", cloudProvider, i+1)
+			}
+			if names[name] {
+				return fmt.Errorf("cloud provider %s: duplicate account name %s", cloudProvider, name)
+			}
+			names[name] = true
+			if _, err := c.ScheduleOf(account); err != nil {
+				return fmt.Errorf("cloud provider %s: account %s: %v", cloudProvider, name, err)
+			}
 		}
-	})
-	return Config
+	}
+	return nil
+}
+
+// GetConfig 获取当前生效的配置，返回值只读，配置热加载时会整体替换
+func GetConfig() *Configuration {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return config
+}
+
+// SetConfig 替换当前生效的配置
+func SetConfig(c *Configuration) {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+	config = c
+}
+
+// AccountKey 账号唯一标识，与缓存 key 的后缀保持一致
+func AccountKey(cloudProvider, cloudName string) string {
+	return cloudProvider + "_" + cloudName
+}
+
+// CacheMaxAge 获取缓存数据的最大保留时间
+func (c *Configuration) CacheMaxAge() (maxAge, certMaxAge time.Duration, err error) {
+	if maxAge, err = time.ParseDuration(pick(c.Cache.MaxAge, DefaultCacheMaxAge)); err != nil || maxAge <= 0 {
+		return 0, 0, fmt.Errorf("invalid max_age: %q", c.Cache.MaxAge)
+	}
+	if certMaxAge, err = time.ParseDuration(pick(c.Cache.CertMaxAge, DefaultCertCacheMaxAge)); err != nil || certMaxAge <= 0 {
+		return 0, 0, fmt.Errorf("invalid cert_max_age: %q", c.Cache.CertMaxAge)
+	}
+	return maxAge, certMaxAge, nil
 }
 
 // InitCache 初始化缓存
 func InitCache() {
-	var err error
-	Cache, err = bigcache.New(context.Background(), bigcache.DefaultConfig(5*time.Minute))
-	if err != nil {
-		logger.Fatal("init cache failed: ", err)
+	maxAge, certMaxAge, _ := GetConfig().CacheMaxAge()
+	Cache = NewStore(maxAge)
+	CertCache = NewStore(certMaxAge)
+}
+
+// InitSnapshot 打开快照文件并加载其中的数据，snapshotFile 为空时不持久化
+func InitSnapshot(snapshotFile string) {
+	if snapshotFile == "" {
+		return
 	}
-	CertCache, err = bigcache.New(context.Background(), bigcache.DefaultConfig(25*time.Hour))
+	db, err := bolt.Open(snapshotFile, 0o600, &bolt.Options{Timeout: 5 * time.Second})
 	if err != nil {
-		logger.Fatal("init cache failed: ", err)
+		logger.Fatal("open snapshot file failed: ", err)
+	}
+	if err := Cache.Persist(db, "cache"); err != nil {
+		logger.Fatal("load snapshot file failed: ", err)
+	}
+	if err := CertCache.Persist(db, "cert_cache"); err != nil {
+		logger.Fatal("load snapshot file failed: ", err)
 	}
+	logger.Info("loaded snapshot file " + snapshotFile)
 }
 
-// GetID 获取唯一ID
-func GetID() string {
-	return xid.New().String()
+// UpdateCacheMaxAge 配置热加载后更新缓存数据的最大保留时间
+func UpdateCacheMaxAge(c *Configuration) {
+	maxAge, certMaxAge, _ := c.CacheMaxAge()
+	Cache.SetMaxAge(maxAge)
+	CertCache.SetMaxAge(certMaxAge)
+}
+
+// GetRecordID 根据记录的身份信息（如域名、记录名、类型、值）生成稳定的记录ID
+// 用于不提供原生记录ID的服务商，相同的输入总是得到相同的ID，避免每次刷新产生新的时间序列
+func GetRecordID(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0}) // 分隔符，避免 "ab"+"c" 与 "a"+"bc" 冲突
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
 }
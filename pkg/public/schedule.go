@@ -0,0 +1,82 @@
+package public
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// 默认采集周期
+const (
+	DefaultDomainSchedule string = "0 */5 * * * *"
+	DefaultRecordSchedule string = "0 */5 * * * *"
+	DefaultCertSchedule   string = "0 0 */1 * * *"
+)
+
+// cronParser 与 cron.WithSeconds() 使用的解析器保持一致
+var cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Schedule 全局采集周期配置，取值可以是时间间隔（如 10m）或6位 cron 表达式（如 0 */5 * * * *）
+type Schedule struct {
+	Domain string `yaml:"domain"` // 域名采集周期
+	Record string `yaml:"record"` // 解析记录采集周期
+	Cert   string `yaml:"cert"`   // 证书采集周期
+	Jitter string `yaml:"jitter"` // 每次执行前的随机延迟上限，避免所有账号同一时刻请求接口
+}
+
+// AccountSchedule 账号最终生效的采集周期，已转换为 cron 表达式
+type AccountSchedule struct {
+	Domain string
+	Record string
+	Cert   string
+	Jitter time.Duration
+}
+
+// ScheduleOf 获取账号生效的采集周期，账号中的 domainSchedule、recordSchedule、certSchedule、jitter 优先于全局配置
+// account 为 nil 时返回全局配置
+func (c *Configuration) ScheduleOf(account map[string]string) (AccountSchedule, error) {
+	var (
+		s   AccountSchedule
+		err error
+	)
+	if s.Domain, err = scheduleSpec(pick(account["domainSchedule"], c.Schedule.Domain, DefaultDomainSchedule)); err != nil {
+		return s, fmt.Errorf("invalid domain schedule: %v", err)
+	}
+	if s.Record, err = scheduleSpec(pick(account["recordSchedule"], c.Schedule.Record, DefaultRecordSchedule)); err != nil {
+		return s, fmt.Errorf("invalid record schedule: %v", err)
+	}
+	if s.Cert, err = scheduleSpec(pick(account["certSchedule"], c.Schedule.Cert, DefaultCertSchedule)); err != nil {
+		return s, fmt.Errorf("invalid cert schedule: %v", err)
+	}
+	if jitter := pick(account["jitter"], c.Schedule.Jitter, ""); jitter != "" {
+		if s.Jitter, err = time.ParseDuration(jitter); err != nil || s.Jitter < 0 {
+			return s, fmt.Errorf("invalid jitter: %q", jitter)
+		}
+	}
+	return s, nil
+}
+
+// scheduleSpec 将时间间隔或 cron 表达式统一转换为 cron 表达式
+func scheduleSpec(value string) (string, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return "", fmt.Errorf("interval must be positive: %q", value)
+		}
+		return "@every " + d.String(), nil
+	}
+	if _, err := cronParser.Parse(value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// pick 返回第一个非空值
+func pick(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+package public
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bryant-rh/cloud_dns_exporter/pkg/public/logger"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrEntryNotFound 缓存中不存在该数据或数据已超过最大保留时间
+var ErrEntryNotFound = errors.New("Entry not found")
+
+// Snapshot 缓存中的一份数据快照
+type Snapshot struct {
+	Data        []byte    // 数据内容
+	CollectedAt time.Time // 采集时间
+	Stale       bool      // 最近一次刷新是否失败，失败时继续提供上一次成功采集的数据
+}
+
+// Store 保存最近一次成功采集的数据，刷新失败时继续提供旧数据，仅在超过最大保留时间后才丢弃
+type Store struct {
+	mu     sync.RWMutex
+	maxAge time.Duration
+	items  map[string]*Snapshot
+	db     *bolt.DB // 快照文件，为空时仅保存在内存中
+	bucket []byte
+}
+
+// NewStore 创建缓存，maxAge 为数据的最大保留时间
+func NewStore(maxAge time.Duration) *Store {
+	return &Store{
+		maxAge: maxAge,
+		items:  make(map[string]*Snapshot),
+	}
+}
+
+// SetMaxAge 修改数据的最大保留时间
+func (s *Store) SetMaxAge(maxAge time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxAge = maxAge
+}
+
+// Set 保存一次成功采集的数据
+func (s *Store) Set(key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := &Snapshot{Data: data, CollectedAt: time.Now()}
+	s.items[key] = snapshot
+	return s.save(key, snapshot)
+}
+
+// Get 获取数据，数据不存在或已超过最大保留时间时返回 ErrEntryNotFound
+func (s *Store) Get(key string) ([]byte, error) {
+	snapshot, ok := s.Snapshot(key)
+	if !ok {
+		return nil, ErrEntryNotFound
+	}
+	return snapshot.Data, nil
+}
+
+// Snapshot 获取数据快照，数据不存在或已超过最大保留时间时返回 false
+func (s *Store) Snapshot(key string) (Snapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot, ok := s.items[key]
+	if !ok {
+		return Snapshot{}, false
+	}
+	if s.maxAge > 0 && time.Since(snapshot.CollectedAt) > s.maxAge {
+		delete(s.items, key)
+		return Snapshot{}, false
+	}
+	return *snapshot, true
+}
+
+// MarkStale 标记数据刷新失败，数据本身保持不变
+func (s *Store) MarkStale(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if snapshot, ok := s.items[key]; ok {
+		snapshot.Stale = true
+	}
+}
+
+// Delete 删除数据
+func (s *Store) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, key)
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Delete([]byte(key))
+	})
+}
+
+// Persist 将缓存持久化到快照文件，并加载快照文件中已有的数据，用于重启后立即提供数据
+func (s *Store) Persist(db *bolt.DB, bucket string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.db = db
+	s.bucket = []byte(bucket)
+	return db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(s.bucket)
+		if err != nil {
+			return err
+		}
+		return b.ForEach(func(k, v []byte) error {
+			snapshot := &Snapshot{}
+			if err := json.Unmarshal(v, snapshot); err != nil {
+				logger.Warning(fmt.Sprintf("[ %s ] skip broken snapshot: %v", k, err))
+				return nil
+			}
+			s.items[string(k)] = snapshot
+			return nil
+		})
+	})
+}
+
+// save 写入快照文件，调用方需持有 s.mu
+func (s *Store) save(key string, snapshot *Snapshot) error {
+	if s.db == nil {
+		return nil
+	}
+	value, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Put([]byte(key), value)
+	})
+}